@@ -0,0 +1,93 @@
+package ctx
+
+import (
+	"math"
+	"time"
+)
+
+// Sub returns the duration c-o. Both values are decoded first, so the result
+// carries the precision of the encoding rather than nanosecond exactness.
+func (c CTX) Sub(o CTX) time.Duration {
+	return c.Time().Sub(o.Time())
+}
+
+// SubChecked returns the duration c-o and true, or false when the span
+// does not fit in a time.Duration. The representable range is itself an
+// int64 nanosecond offset either side of the epoch, so spans between times
+// on opposite sides can exceed it; Sub saturates in that case.
+func (c CTX) SubChecked(o CTX) (time.Duration, bool) {
+	a, b := c.nanos(), o.nanos()
+	d := a - b
+	if (a^b) < 0 && (a^d) < 0 {
+		return 0, false
+	}
+	return time.Duration(d), true
+}
+
+// Add returns c shifted by d. Results past the representable range, an
+// int64 nanosecond offset from the epoch, saturate at its ends instead of
+// wrapping.
+func (c CTX) Add(d time.Duration) CTX {
+	n := c.nanos()
+	sum := n + int64(d)
+	switch {
+	case d > 0 && sum < n:
+		sum = math.MaxInt64
+	case d < 0 && sum > n:
+		sum = math.MinInt64
+	}
+	return fromNanos(sum)
+}
+
+// Truncate returns c rounded down to a multiple of d, as time.Time.Truncate
+// does, and re-encoded. If d <= 0, c is returned unchanged.
+func (c CTX) Truncate(d time.Duration) CTX {
+	if d <= 0 {
+		return c
+	}
+	return NewCTX(c.Time().Truncate(d))
+}
+
+// Round returns c rounded to the nearest multiple of d, as time.Time.Round
+// does, and re-encoded. If d <= 0, c is returned unchanged.
+func (c CTX) Round(d time.Duration) CTX {
+	if d <= 0 {
+		return c
+	}
+	return NewCTX(c.Time().Round(d))
+}
+
+// Next returns the earliest encodable instant after c, one tick later at
+// the resolution of the scale in use. At MaxTime it returns c's canonical
+// form unchanged.
+func (c CTX) Next() CTX {
+	n := c.nanos()
+	if n < 0 {
+		return fromNanos(n + 1)
+	}
+	return stepAway(n, 1)
+}
+
+// Prev returns the latest encodable instant before c, one tick earlier at
+// the resolution of the scale in use. At MinTime it returns c's canonical
+// form unchanged.
+func (c CTX) Prev() CTX {
+	n := c.nanos()
+	if n > 0 {
+		return fromNanos(n - 1)
+	}
+	return stepAway(n, -1)
+}
+
+// stepAway returns the encoding one tick farther from the epoch than the
+// offset n, on the side given by sign. Stepping toward the epoch needs no
+// helper: the encoding of the offset one nanosecond closer is the adjacent
+// tick.
+func stepAway(n, sign int64) CTX {
+	canon := fromNanos(n)
+	mag, ok := canon.nextMagnitude()
+	if !ok {
+		return canon
+	}
+	return fromNanos(sign * int64(mag))
+}
@@ -0,0 +1,154 @@
+package ctx
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSub(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		a, b time.Time
+	}{
+		{"near_epoch", time.Unix(0, 0).Add(time.Second), time.Unix(0, 0).Add(250 * time.Millisecond)},
+		{"hour", now.Add(time.Hour), now},
+		{"year_back", now.Add(-365 * 24 * time.Hour), now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCTX(tt.a).Sub(NewCTX(tt.b))
+			want := tt.a.Sub(tt.b)
+			if diff := (got - want).Abs(); diff > tolerance(tt.a)+tolerance(tt.b) {
+				t.Errorf("Sub = %v, want %v (diff %v)", got, want, diff)
+			}
+		})
+	}
+}
+
+func TestAdd(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	base := NewCTX(epoch.Add(time.Second))
+
+	if got, want := base.Add(500*time.Millisecond), NewCTX(epoch.Add(1500*time.Millisecond)); got != want {
+		t.Errorf("forward Add = %v, want %v", got, want)
+	}
+	if got, want := base.Add(-3*time.Second), NewCTX(epoch.Add(-2*time.Second)); got != want {
+		t.Errorf("backward Add = %v, want %v", got, want)
+	}
+
+	now := time.Now()
+	shifted := NewCTX(now).Add(time.Hour).Time()
+	if diff := shifted.Sub(now.Add(time.Hour)).Abs(); diff > 2*tolerance(now) {
+		t.Errorf("Add(1h) = %v, want about %v", shifted, now.Add(time.Hour))
+	}
+
+	top := NewCTX(time.Unix(0, math.MaxInt64))
+	if got := top.Add(time.Hour); got != fromNanos(math.MaxInt64) {
+		t.Errorf("Add past the end = %v, want saturated %v", got, fromNanos(math.MaxInt64))
+	}
+	bottom := NewCTX(time.Unix(0, math.MinInt64))
+	if got := bottom.Add(-time.Hour); got != fromNanos(math.MinInt64) {
+		t.Errorf("Add before the start = %v, want saturated %v", got, fromNanos(math.MinInt64))
+	}
+}
+
+func TestTruncateRound(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	ct := NewCTX(epoch.Add(1234567 * time.Microsecond))
+
+	if got, want := ct.Truncate(time.Second), NewCTX(epoch.Add(time.Second)); got != want {
+		t.Errorf("Truncate(1s) = %v, want %v", got, want)
+	}
+	if got, want := ct.Round(time.Millisecond), NewCTX(epoch.Add(1235*time.Millisecond)); got != want {
+		t.Errorf("Round(1ms) = %v, want %v", got, want)
+	}
+	if got, want := ct.Round(time.Second), NewCTX(epoch.Add(time.Second)); got != want {
+		t.Errorf("Round(1s) = %v, want %v", got, want)
+	}
+
+	odd := CTX(scaleMicro<<scaleShift | 5<<valueShift | 7)
+	if got := odd.Truncate(0); got != odd {
+		t.Errorf("Truncate(0) = %08X, want %08X", uint32(got), uint32(odd))
+	}
+	if got := odd.Round(-time.Second); got != odd {
+		t.Errorf("Round(-1s) = %08X, want %08X", uint32(got), uint32(odd))
+	}
+}
+
+func TestNextPrev(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	samples := []CTX{0}
+	for _, d := range []time.Duration{1, 999, 131071, 131072, 131073, time.Second, 131071999999, 131072 * time.Second, 400 * 24 * time.Hour} {
+		samples = append(samples, NewCTX(epoch.Add(d)), NewCTX(epoch.Add(-d)))
+	}
+	for _, tm := range sampleTimes(50) {
+		samples = append(samples, NewCTX(tm))
+	}
+
+	for _, c := range samples {
+		next, prev := c.Next(), c.Prev()
+		if !next.Time().After(c.Time()) {
+			t.Errorf("%#v.Next() = %#v, not after", c, next)
+		}
+		if !prev.Time().Before(c.Time()) {
+			t.Errorf("%#v.Prev() = %#v, not before", c, prev)
+		}
+		if next.Prev() != c || prev.Next() != c {
+			t.Errorf("%#v: Next and Prev do not invert: %#v, %#v", c, next.Prev(), prev.Next())
+		}
+
+		// Nothing encodes strictly between c and Next
+		if mid := NewCTX(c.Time().Add(next.Time().Sub(c.Time()) / 2)); mid != c && mid != next {
+			t.Errorf("%#v: %#v lies between it and Next %#v", c, mid, next)
+		}
+
+		// Within a scale the step is one resolution unit, up to rounding. The
+		// first tick of a coarser unit is clamped to the scale boundary, so
+		// the step from it is shorter.
+		if c.exponent() == next.exponent() && c.exponent() == prev.exponent() {
+			if step := next.Time().Sub(c.Time()); step < c.Resolution()-1 || step > c.Resolution() {
+				t.Errorf("%#v.Next() is %v later, want %v", c, step, c.Resolution())
+			}
+		}
+	}
+
+	if max := NewCTX(MaxTime()); max.Next() != max {
+		t.Errorf("Next at MaxTime = %#v, want saturation", max.Next())
+	}
+	if min := NewCTX(MinTime()); min.Prev() != min {
+		t.Errorf("Prev at MinTime = %#v, want saturation", min.Prev())
+	}
+}
+
+func TestSubChecked(t *testing.T) {
+	lo, hi := NewCTX(MinTime()), NewCTX(MaxTime())
+	epoch := CTX(0)
+	now := NewCTX(time.Now())
+
+	tests := []struct {
+		name string
+		c, o CTX
+		ok   bool
+	}{
+		{"widest span", hi, lo, false},
+		{"widest span reversed", lo, hi, false},
+		{"max to epoch", hi, epoch, true},
+		{"min to epoch", lo, epoch, true},
+		{"now to min", now, lo, false},
+		{"now to max", now, hi, true},
+		{"same", now, now, true},
+	}
+	for _, tt := range tests {
+		d, ok := tt.c.SubChecked(tt.o)
+		if ok != tt.ok {
+			t.Errorf("%s: SubChecked ok = %v, want %v", tt.name, ok, tt.ok)
+			continue
+		}
+		if ok && d != tt.c.Sub(tt.o) {
+			t.Errorf("%s: SubChecked = %v, want Sub %v", tt.name, d, tt.c.Sub(tt.o))
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package ctx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ctxSize is the number of bytes in an encoded CTX.
+const ctxSize = 4
+
+// EncodeAll encodes each time in ts and writes the results back to back.
+func EncodeAll(ts []time.Time) []byte {
+	b := make([]byte, 0, len(ts)*ctxSize)
+	for _, t := range ts {
+		b = NewCTX(t).AppendBytes(b)
+	}
+	return b
+}
+
+// EncodeSlice encodes each time in ts, allocating the result once.
+func EncodeSlice(ts []time.Time) []CTX {
+	return mapSlice(ts, NewCTX)
+}
+
+// DecodeSlice decodes each value in cs, allocating the result once.
+func DecodeSlice(cs []CTX) []time.Time {
+	return mapSlice(cs, CTX.Time)
+}
+
+// mapSlice returns f applied to each element of s.
+func mapSlice[S, D any](s []S, f func(S) D) []D {
+	d := make([]D, len(s))
+	for i, v := range s {
+		d[i] = f(v)
+	}
+	return d
+}
+
+// CountRecords returns the number of 4-byte records in b, as written by
+// EncodeAll, without decoding them. It returns an error wrapping
+// ErrInvalidLength if b ends with a partial record.
+func CountRecords(b []byte) (int, error) {
+	if len(b)%ctxSize != 0 {
+		return 0, fmt.Errorf("%w: %d bytes is not a whole number of %d-byte records",
+			ErrInvalidLength, len(b), ctxSize)
+	}
+	return len(b) / ctxSize, nil
+}
+
+// DecodeAll decodes the output of EncodeAll. It returns an error wrapping
+// ErrInvalidLength if b ends with a partial record.
+func DecodeAll(b []byte) ([]time.Time, error) {
+	n, err := CountRecords(b)
+	if err != nil {
+		return nil, err
+	}
+	ts := make([]time.Time, n)
+	for i := range ts {
+		ts[i] = FromBytes(b[i*ctxSize : (i+1)*ctxSize]).Time()
+	}
+	return ts, nil
+}
+
+// DiffStreams compares two streams of 4-byte records, as written by
+// EncodeAll, in lockstep. It returns the index of the first record whose
+// decoded instants differ and how much later the record in b is, or -1 if
+// no record differs. If the shared records match but one stream is longer,
+// index is the length of the shorter one and the error wraps
+// ErrLengthMismatch. A stream ending with a partial record gives an error
+// wrapping ErrInvalidLength.
+func DiffStreams(a, b []byte) (index int, delta time.Duration, err error) {
+	na, err := CountRecords(a)
+	if err != nil {
+		return -1, 0, err
+	}
+	nb, err := CountRecords(b)
+	if err != nil {
+		return -1, 0, err
+	}
+	for i := 0; i < min(na, nb); i++ {
+		ca := FromBytes(a[i*ctxSize : (i+1)*ctxSize])
+		cb := FromBytes(b[i*ctxSize : (i+1)*ctxSize])
+		if !ca.Equal(cb) {
+			return i, cb.Sub(ca), nil
+		}
+	}
+	if na != nb {
+		return min(na, nb), 0, fmt.Errorf("%w: %d and %d records", ErrLengthMismatch, na, nb)
+	}
+	return -1, 0, nil
+}
+
+// EncodeDeltas encodes a sequence of times compactly: the first value is
+// written in full and each later one as a signed varint holding the number
+// of encoding ticks since the previous value. Closely spaced timestamps
+// usually take a single byte each. The result is decoded by DecodeDeltas.
+func EncodeDeltas(ts []time.Time) []byte {
+	if len(ts) == 0 {
+		return nil
+	}
+	first := NewCTX(ts[0])
+	b := first.AppendBytes(make([]byte, 0, ctxSize+len(ts)-1))
+	prev := first.sortKey()
+	for _, t := range ts[1:] {
+		k := NewCTX(t).sortKey()
+		b = binary.AppendVarint(b, int64(k)-int64(prev))
+		prev = k
+	}
+	return b
+}
+
+// DecodeDeltas decodes the output of EncodeDeltas.
+func DecodeDeltas(b []byte) ([]time.Time, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	first, err := ParseBytes(b[:min(len(b), ctxSize)])
+	if err != nil {
+		return nil, err
+	}
+	ts := []time.Time{first.Time()}
+	k := int64(first.sortKey())
+	for i := ctxSize; i < len(b); {
+		d, n := binary.Varint(b[i:])
+		if n <= 0 {
+			return nil, fmt.Errorf("%w: malformed delta at byte %d", ErrInvalidFormat, i)
+		}
+		k += d
+		if k < 0 || k > math.MaxUint32 {
+			return nil, fmt.Errorf("%w: delta at byte %d leaves the encodable range", ErrOutOfRange, i)
+		}
+		ts = append(ts, fromSortKey(uint32(k)).Time())
+		i += n
+	}
+	return ts, nil
+}
@@ -0,0 +1,201 @@
+package ctx
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func sampleTimes(n int) []time.Time {
+	start := time.Now()
+	ts := make([]time.Time, n)
+	for i := range ts {
+		ts[i] = start.Add(time.Duration(i) * 37 * time.Minute)
+	}
+	return ts
+}
+
+func TestEncodeAll(t *testing.T) {
+	ts := sampleTimes(1000)
+	b := EncodeAll(ts)
+	if len(b) != len(ts)*4 {
+		t.Fatalf("EncodeAll wrote %d bytes, want %d", len(b), len(ts)*4)
+	}
+
+	got, err := DecodeAll(b)
+	if err != nil {
+		t.Fatalf("DecodeAll: %v", err)
+	}
+	if len(got) != len(ts) {
+		t.Fatalf("DecodeAll returned %d times, want %d", len(got), len(ts))
+	}
+	for i := range ts {
+		if want := NewCTX(ts[i]).Time(); !got[i].Equal(want) {
+			t.Errorf("record %d = %v, want %v", i, got[i], want)
+		}
+	}
+
+	if _, err := DecodeAll(b[:len(b)-1]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("DecodeAll(partial): err = %v, want ErrInvalidLength", err)
+	}
+	if got, err := DecodeAll(nil); err != nil || len(got) != 0 {
+		t.Errorf("DecodeAll(nil) = %v, %v; want empty, nil", got, err)
+	}
+}
+
+func TestCountRecords(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 100} {
+		b := EncodeAll(sampleTimes(n))
+		if got, err := CountRecords(b); err != nil || got != n {
+			t.Errorf("CountRecords(%d records) = %d, %v", n, got, err)
+		}
+		if n == 0 {
+			continue
+		}
+		if _, err := CountRecords(b[:len(b)-1]); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("CountRecords(%d records minus a byte) error = %v, want ErrInvalidLength", n, err)
+		}
+	}
+}
+
+func TestDiffStreams(t *testing.T) {
+	ts := sampleTimes(20)
+	a := EncodeAll(ts)
+
+	if i, d, err := DiffStreams(a, EncodeAll(ts)); i != -1 || d != 0 || err != nil {
+		t.Errorf("identical streams: DiffStreams = %d, %v, %v; want -1, 0, nil", i, d, err)
+	}
+
+	changed := slices.Clone(ts)
+	changed[7] = changed[7].Add(24 * time.Hour)
+	i, d, err := DiffStreams(a, EncodeAll(changed))
+	if err != nil || i != 7 {
+		t.Fatalf("one-record difference: DiffStreams = %d, %v, %v; want index 7", i, d, err)
+	}
+	if want := NewCTX(changed[7]).Sub(NewCTX(ts[7])); d != want {
+		t.Errorf("one-record difference: delta = %v, want %v", d, want)
+	}
+
+	i, _, err = DiffStreams(a, a[:12*ctxSize])
+	if !errors.Is(err, ErrLengthMismatch) || i != 12 {
+		t.Errorf("shorter stream: DiffStreams = %d, %v; want 12, ErrLengthMismatch", i, err)
+	}
+	if _, _, err := DiffStreams(a, a[:len(a)-1]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("partial record: DiffStreams error = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestEncodeDeltas(t *testing.T) {
+	// A log stream: one entry every 250ms with some jitter
+	start := time.Now()
+	ts := make([]time.Time, 1000)
+	for i := range ts {
+		ts[i] = start.Add(time.Duration(i)*250*time.Millisecond + time.Duration(i%7)*time.Millisecond)
+	}
+
+	b := EncodeDeltas(ts)
+	if full := len(EncodeAll(ts)); len(b) >= full {
+		t.Errorf("EncodeDeltas wrote %d bytes, want fewer than EncodeAll's %d", len(b), full)
+	}
+
+	got, err := DecodeDeltas(b)
+	if err != nil {
+		t.Fatalf("DecodeDeltas: %v", err)
+	}
+	if len(got) != len(ts) {
+		t.Fatalf("DecodeDeltas returned %d times, want %d", len(got), len(ts))
+	}
+	for i := range ts {
+		if want := NewCTX(ts[i]).Time(); !got[i].Equal(want) {
+			t.Errorf("record %d = %v, want %v", i, got[i], want)
+		}
+	}
+}
+
+func TestEncodeDeltasMixed(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	ts := []time.Time{
+		epoch.Add(time.Second),
+		epoch.Add(-time.Hour),
+		epoch,
+		epoch.Add(3 * time.Microsecond),
+		time.Now(),
+		epoch.Add(time.Second),
+	}
+
+	got, err := DecodeDeltas(EncodeDeltas(ts))
+	if err != nil {
+		t.Fatalf("DecodeDeltas: %v", err)
+	}
+	for i := range ts {
+		if want := NewCTX(ts[i]).Time(); !got[i].Equal(want) {
+			t.Errorf("record %d = %v, want %v", i, got[i], want)
+		}
+	}
+
+	if _, err := DecodeDeltas([]byte{1, 2}); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("DecodeDeltas(short): err = %v, want ErrInvalidLength", err)
+	}
+	if _, err := DecodeDeltas([]byte{0, 0, 0, 0, 0x80}); err == nil {
+		t.Errorf("DecodeDeltas(truncated varint): expected error")
+	}
+}
+
+func TestEncodeSlice(t *testing.T) {
+	ts := sampleTimes(100)
+	cs := EncodeSlice(ts)
+	got := DecodeSlice(cs)
+	if len(cs) != len(ts) || len(got) != len(ts) {
+		t.Fatalf("lengths = %d, %d; want %d", len(cs), len(got), len(ts))
+	}
+	for i := range ts {
+		if cs[i] != NewCTX(ts[i]) {
+			t.Errorf("EncodeSlice[%d] = %v, want %v", i, cs[i], NewCTX(ts[i]))
+		}
+		if want := NewCTX(ts[i]).Time(); !got[i].Equal(want) {
+			t.Errorf("DecodeSlice[%d] = %v, want %v", i, got[i], want)
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(10, func() { EncodeSlice(ts) }); allocs != 1 {
+		t.Errorf("EncodeSlice allocates %v times, want 1", allocs)
+	}
+	if allocs := testing.AllocsPerRun(10, func() { DecodeSlice(cs) }); allocs != 1 {
+		t.Errorf("DecodeSlice allocates %v times, want 1", allocs)
+	}
+}
+
+func BenchmarkEncodeAll(b *testing.B) {
+	ts := sampleTimes(1000)
+	buf := EncodeAll(ts)
+	cs := EncodeSlice(ts)
+
+	b.Run("EncodeAll", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = EncodeAll(ts)
+		}
+	})
+
+	b.Run("DecodeAll", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = DecodeAll(buf)
+		}
+	})
+
+	b.Run("EncodeSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = EncodeSlice(ts)
+		}
+	})
+
+	b.Run("DecodeSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = DecodeSlice(cs)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package ctx
+
+import "fmt"
+
+// BitWriter packs values into a byte slice most significant bit first, with
+// no padding between them. The zero value is ready to use.
+type BitWriter struct {
+	buf   []byte
+	nbits int
+}
+
+// WriteBits writes the low n bits of v, for n from 0 to 64.
+func (w *BitWriter) WriteBits(v uint64, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.nbits%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if v>>i&1 != 0 {
+			w.buf[len(w.buf)-1] |= 0x80 >> (w.nbits % 8)
+		}
+		w.nbits++
+	}
+}
+
+// Len returns the number of bits written.
+func (w *BitWriter) Len() int {
+	return w.nbits
+}
+
+// Bytes returns the packed bits, with the last byte padded with zero bits.
+func (w *BitWriter) Bytes() []byte {
+	return w.buf
+}
+
+// BitReader reads values packed by a BitWriter.
+type BitReader struct {
+	buf []byte
+	pos int
+}
+
+// NewBitReader returns a BitReader reading from b.
+func NewBitReader(b []byte) *BitReader {
+	return &BitReader{buf: b}
+}
+
+// ReadBits reads the next n bits, for n from 0 to 64. It returns an error
+// wrapping ErrInvalidLength if fewer than n bits remain.
+func (r *BitReader) ReadBits(n int) (uint64, error) {
+	if left := len(r.buf)*8 - r.pos; n > left {
+		return 0, fmt.Errorf("%w: need %d bits, have %d", ErrInvalidLength, n, left)
+	}
+	var v uint64
+	for i := 0; i < n; i++ {
+		bit := r.buf[r.pos/8] >> (7 - r.pos%8) & 1
+		v = v<<1 | uint64(bit)
+		r.pos++
+	}
+	return v, nil
+}
+
+// AppendBits writes the 32 bits of c to w.
+func (c CTX) AppendBits(w *BitWriter) {
+	w.WriteBits(uint64(c), 32)
+}
+
+// ReadBits reads a CTX written by AppendBits from r.
+func ReadBits(r *BitReader) (CTX, error) {
+	v, err := r.ReadBits(32)
+	return CTX(v), err
+}
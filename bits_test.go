@@ -0,0 +1,39 @@
+package ctx
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBits(t *testing.T) {
+	first, second := NewCTX(time.Now()), NewCTX(time.Unix(0, 0).Add(-1500*time.Millisecond))
+
+	var w BitWriter
+	w.WriteBits(0xA, 4) // flags
+	first.AppendBits(&w)
+	w.WriteBits(1, 1)
+	second.AppendBits(&w)
+	if w.Len() != 69 || len(w.Bytes()) != 9 {
+		t.Fatalf("wrote %d bits in %d bytes, want 69 in 9", w.Len(), len(w.Bytes()))
+	}
+
+	r := NewBitReader(w.Bytes())
+	if flags, err := r.ReadBits(4); err != nil || flags != 0xA {
+		t.Errorf("flags = %X, %v; want A", flags, err)
+	}
+	if got, err := ReadBits(r); err != nil || got != first {
+		t.Errorf("first = %#v, %v; want %#v", got, err, first)
+	}
+	if bit, err := r.ReadBits(1); err != nil || bit != 1 {
+		t.Errorf("bit = %d, %v; want 1", bit, err)
+	}
+	if got, err := ReadBits(r); err != nil || got != second {
+		t.Errorf("second = %#v, %v; want %#v", got, err, second)
+	}
+
+	// Only the 3 padding bits remain
+	if _, err := ReadBits(r); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("ReadBits past the end = %v, want ErrInvalidLength", err)
+	}
+}
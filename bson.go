@@ -0,0 +1,34 @@
+package ctx
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// bsonDateTime is the BSON element type of a UTC datetime: a little-endian
+// int64 count of milliseconds since the Unix epoch.
+const bsonDateTime = 0x09
+
+// MarshalBSONValue implements the ValueMarshaler interface of
+// go.mongodb.org/mongo-driver/v2/bson without depending on it, storing the
+// decoded time as a native BSON datetime. The millisecond is chosen inside
+// the tick c decodes to, so UnmarshalBSONValue restores c exactly whenever
+// c.Resolution() is at least a millisecond; finer ticks lose their
+// sub-millisecond part.
+func (c CTX) MarshalBSONValue() (byte, []byte, error) {
+	return bsonDateTime, binary.LittleEndian.AppendUint64(nil, uint64(c.unixMilliInTick())), nil
+}
+
+// UnmarshalBSONValue implements the ValueUnmarshaler interface of
+// go.mongodb.org/mongo-driver/v2/bson, decoding a BSON datetime with
+// FromUnixMilli.
+func (c *CTX) UnmarshalBSONValue(typ byte, data []byte) error {
+	if typ != bsonDateTime {
+		return fmt.Errorf("%w: BSON type 0x%02X is not a datetime", ErrInvalidFormat, typ)
+	}
+	if len(data) != 8 {
+		return fmt.Errorf("%w: got %d bytes, want 8", ErrInvalidLength, len(data))
+	}
+	*c = FromUnixMilli(int64(binary.LittleEndian.Uint64(data)))
+	return nil
+}
@@ -0,0 +1,65 @@
+package ctx
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBSON(t *testing.T) {
+	// 2024-03-15T12:30:45.123Z is 1710505845123 ms, 0x18E4217E183
+	want := []byte{0x83, 0xE1, 0x17, 0x42, 0x8E, 0x01, 0x00, 0x00}
+	var got CTX
+	if err := got.UnmarshalBSONValue(bsonDateTime, want); err != nil {
+		t.Fatalf("UnmarshalBSONValue: %v", err)
+	}
+	if c := FromUnixMilli(1710505845123); got != c {
+		t.Errorf("UnmarshalBSONValue = %v, want %v", got, c)
+	}
+
+	exact := NewCTX(Epoch().Add(-90*time.Second + 250*time.Millisecond))
+	typ, data, err := exact.MarshalBSONValue()
+	if err != nil {
+		t.Fatalf("MarshalBSONValue: %v", err)
+	}
+	if typ != bsonDateTime || !bytes.Equal(data, []byte{0x6A, 0xA1, 0xFE, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("MarshalBSONValue = 0x%02X % X, want -89750 ms", typ, data)
+	}
+
+	epoch := Epoch()
+	times := append(sampleTimes(50), exact.Time(),
+		epoch.Add(time.Hour+123456789),
+		epoch.Add(100*24*time.Hour+7),
+		epoch.Add(4*24*time.Hour+13*time.Hour+16*time.Minute+54*time.Second+62500*time.Microsecond),
+		epoch.Add(54*time.Minute+6*time.Second+855468750),
+		epoch.Add(-(time.Hour + 123456789)),
+		epoch.Add(3*365*24*time.Hour+1),
+		epoch.Add(1234567*time.Microsecond))
+	for _, tm := range times {
+		ct := NewCTX(tm)
+		typ, data, err := ct.MarshalBSONValue()
+		if err != nil {
+			t.Fatalf("MarshalBSONValue: %v", err)
+		}
+		var back CTX
+		if err := back.UnmarshalBSONValue(typ, data); err != nil {
+			t.Fatalf("UnmarshalBSONValue: %v", err)
+		}
+		if ct.Resolution() >= time.Millisecond {
+			if back != ct {
+				t.Errorf("round-trip of %v = %v, want unchanged", ct, back)
+			}
+		} else if diff := back.Sub(ct).Abs(); diff >= time.Millisecond {
+			t.Errorf("round-trip of %v = %v, %v away", ct, back, diff)
+		}
+	}
+
+	var c CTX
+	if err := c.UnmarshalBSONValue(0x12, make([]byte, 8)); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("UnmarshalBSONValue(int64) error = %v, want ErrInvalidFormat", err)
+	}
+	if err := c.UnmarshalBSONValue(bsonDateTime, make([]byte, 4)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("UnmarshalBSONValue(short) error = %v, want ErrInvalidLength", err)
+	}
+}
@@ -0,0 +1,117 @@
+package ctx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// CBOR major types and the tag 1 (epoch-based date/time) head.
+const (
+	cborUint     = 0
+	cborNegative = 1
+	cborTagEpoch = 0xC1
+	cborFloat32  = 0xFA
+	cborFloat64  = 0xFB
+)
+
+var errCBOR = fmt.Errorf("%w: CBOR value is not a tag 1 epoch timestamp", ErrInvalidFormat)
+
+// MarshalCBOR implements the Marshaler interface of github.com/fxamacker/cbor
+// without depending on it. The decoded time is written as a tag 1 epoch
+// timestamp: an integer when it falls on a whole second, a float64 otherwise.
+func (c CTX) MarshalCBOR() ([]byte, error) {
+	t := c.Time()
+	b := []byte{cborTagEpoch}
+	if t.Nanosecond() != 0 {
+		f := float64(t.Unix()) + float64(t.Nanosecond())/1e9
+		b = append(b, cborFloat64)
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(f)), nil
+	}
+	if sec := t.Unix(); sec >= 0 {
+		return appendCBORHead(b, cborUint, uint64(sec)), nil
+	} else {
+		return appendCBORHead(b, cborNegative, uint64(-1-sec)), nil
+	}
+}
+
+// UnmarshalCBOR implements the Unmarshaler interface of
+// github.com/fxamacker/cbor. It accepts a tag 1 timestamp holding an integer
+// or a float32/float64 number of seconds and encodes it with NewCTX.
+func (c *CTX) UnmarshalCBOR(b []byte) error {
+	if len(b) < 2 || b[0] != cborTagEpoch {
+		return errCBOR
+	}
+	b = b[1:]
+
+	var t time.Time
+	switch b[0] {
+	case cborFloat32, cborFloat64:
+		var f float64
+		switch {
+		case b[0] == cborFloat32 && len(b) == 5:
+			f = float64(math.Float32frombits(binary.BigEndian.Uint32(b[1:])))
+		case b[0] == cborFloat64 && len(b) == 9:
+			f = math.Float64frombits(binary.BigEndian.Uint64(b[1:]))
+		default:
+			return errCBOR
+		}
+		if math.IsNaN(f) || math.Abs(f) >= math.MaxInt64 {
+			return errCBOR
+		}
+		sec, frac := math.Modf(f)
+		t = time.Unix(int64(sec), int64(math.Round(frac*1e9)))
+	default:
+		major := b[0] >> 5
+		n, rest, ok := readCBORHead(b)
+		if !ok || len(rest) != 0 || n > math.MaxInt64 {
+			return errCBOR
+		}
+		switch major {
+		case cborUint:
+			t = time.Unix(int64(n), 0)
+		case cborNegative:
+			t = time.Unix(-1-int64(n), 0)
+		default:
+			return errCBOR
+		}
+	}
+	*c = NewCTX(t)
+	return nil
+}
+
+// appendCBORHead appends the initial byte and argument of a data item.
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), n)
+}
+
+// readCBORHead reads the argument of the data item at the start of b.
+func readCBORHead(b []byte) (n uint64, rest []byte, ok bool) {
+	info := b[0] & 0x1F
+	b = b[1:]
+	switch {
+	case info < 24:
+		return uint64(info), b, true
+	case info == 24 && len(b) >= 1:
+		return uint64(b[0]), b[1:], true
+	case info == 25 && len(b) >= 2:
+		return uint64(binary.BigEndian.Uint16(b)), b[2:], true
+	case info == 26 && len(b) >= 4:
+		return uint64(binary.BigEndian.Uint32(b)), b[4:], true
+	case info == 27 && len(b) >= 8:
+		return binary.BigEndian.Uint64(b), b[8:], true
+	}
+	return 0, nil, false
+}
@@ -0,0 +1,95 @@
+package ctx
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestCBOR(t *testing.T) {
+	epoch := Epoch()
+	tests := []struct {
+		name string
+		ct   CTX
+		want []byte
+	}{
+		{"zero", 0, []byte{0xC1, 0x00}},
+		{"90s", NewCTX(epoch.Add(90 * time.Second)), []byte{0xC1, 0x18, 0x5A}},
+		{"-1s", NewCTX(epoch.Add(-time.Second)), []byte{0xC1, 0x20}},
+		{"-1.5s", NewCTX(epoch.Add(-1500 * time.Millisecond)), []byte{0xC1, 0xFB, 0xBF, 0xF8, 0, 0, 0, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.ct.MarshalCBOR()
+			if err != nil {
+				t.Fatalf("MarshalCBOR: %v", err)
+			}
+			if !bytes.Equal(b, tt.want) {
+				t.Errorf("MarshalCBOR = % X, want % X", b, tt.want)
+			}
+			var got CTX
+			if err := got.UnmarshalCBOR(b); err != nil {
+				t.Fatalf("UnmarshalCBOR: %v", err)
+			}
+			if got != tt.ct {
+				t.Errorf("UnmarshalCBOR = %v, want %v", got, tt.ct)
+			}
+		})
+	}
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	for _, tm := range []time.Time{time.Now(), Epoch().Add(1234567 * time.Microsecond), MinTime(), MaxTime()} {
+		ct := NewCTX(tm)
+		b, err := ct.MarshalCBOR()
+		if err != nil {
+			t.Fatalf("MarshalCBOR: %v", err)
+		}
+		var got CTX
+		if err := got.UnmarshalCBOR(b); err != nil {
+			t.Fatalf("UnmarshalCBOR(% X): %v", b, err)
+		}
+		if got != ct {
+			t.Errorf("round-trip of %v = %v via % X", ct, got, b)
+		}
+	}
+}
+
+func TestCBORDecodeRFC8949(t *testing.T) {
+	// Examples from RFC 8949 appendix A
+	tests := []struct {
+		name string
+		in   []byte
+		want time.Time
+	}{
+		{"integer", []byte{0xC1, 0x1A, 0x51, 0x4B, 0x67, 0xB0}, time.Unix(1363896240, 0)},
+		{"float", []byte{0xC1, 0xFB, 0x41, 0xD4, 0x52, 0xD9, 0xEC, 0x20, 0x00, 0x00}, time.Unix(1363896240, 5e8)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got CTX
+			if err := got.UnmarshalCBOR(tt.in); err != nil {
+				t.Fatalf("UnmarshalCBOR: %v", err)
+			}
+			if want := NewCTX(tt.want); got != want {
+				t.Errorf("UnmarshalCBOR = %v, want %v", got, want)
+			}
+		})
+	}
+
+	for _, bad := range [][]byte{
+		nil,
+		{0xC0, 0x00},                   // tag 0
+		{0xC1},                         // missing content
+		{0xC1, 0x1A, 0x51},             // truncated integer
+		{0xC1, 0x00, 0x00},             // trailing data
+		{0xC1, 0x60},                   // text string
+		{0xC1, 0xFB, 0x7F, 0xF8, 0, 0}, // truncated float
+	} {
+		var c CTX
+		if err := c.UnmarshalCBOR(bad); err == nil {
+			t.Errorf("UnmarshalCBOR(% X): expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package ctx
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// defaultCodec backs the package-level functions, measuring offsets from the
+// Unix epoch.
+var defaultCodec = NewCodec(time.Unix(0, 0))
+
+// Codec encodes and decodes CTX values relative to a chosen epoch. Precision
+// is finest close to the epoch, so pick one near the data being stored.
+type Codec struct {
+	epoch time.Time
+}
+
+// NewCodec returns a Codec that measures offsets from epoch.
+func NewCodec(epoch time.Time) *Codec {
+	return &Codec{epoch: epoch.UTC()}
+}
+
+// Epoch returns the codec's epoch in UTC.
+func (cd *Codec) Epoch() time.Time {
+	return cd.epoch
+}
+
+// Encode encodes t as an offset from the codec's epoch. Only the instant
+// matters, so the same instant in any location encodes identically. Instants
+// before the epoch set the sign bit; the epoch itself always encodes to zero.
+// Offsets that do not fit in int64 nanoseconds are clamped.
+func (cd *Codec) Encode(t time.Time) CTX {
+	// Sub saturates instead of wrapping. The epoch carries no monotonic
+	// reading, so Sub compares wall clocks and t's monotonic reading is
+	// never used.
+	return fromNanos(int64(t.Sub(cd.epoch)))
+}
+
+// Decode returns the instant c represents relative to the codec's epoch, in
+// UTC.
+func (cd *Codec) Decode(c CTX) time.Time {
+	return cd.epoch.Add(time.Duration(c.nanos()))
+}
+
+// bounds returns the earliest and latest instants the codec can encode.
+func (cd *Codec) bounds() (time.Time, time.Time) {
+	return cd.epoch.Add(math.MinInt64), cd.epoch.Add(math.MaxInt64)
+}
+
+func (cd *Codec) encodeChecked(t time.Time) (CTX, error) {
+	lo, hi := cd.bounds()
+	if t.Before(lo) || t.After(hi) {
+		return 0, fmt.Errorf("%w: time %v outside representable range [%v, %v]",
+			ErrOutOfRange, t.UTC(), lo.UTC(), hi.UTC())
+	}
+	return cd.Encode(t), nil
+}
+
+// Rebase returns c, encoded against oldEpoch, re-encoded against newEpoch.
+// The absolute instant is preserved to the resolution of the new encoding,
+// which is coarser when newEpoch is farther from it.
+func (c CTX) Rebase(oldEpoch, newEpoch time.Time) CTX {
+	return NewCTXRelative(c.TimeRelative(oldEpoch), newEpoch)
+}
+
+// NewCTXRelative encodes t as an offset from ref rather than the epoch, as
+// NewCodec(ref).Encode(t) would. Decode it with TimeRelative and the same
+// ref.
+func NewCTXRelative(t, ref time.Time) CTX {
+	return NewCodec(ref).Encode(t)
+}
+
+// TimeRelative returns the instant c represents as an offset from ref, in
+// UTC.
+func (c CTX) TimeRelative(ref time.Time) time.Time {
+	return ref.Add(time.Duration(c.nanos())).UTC()
+}
+
+// RelativeCodec encodes a self-describing stream in which the first time is
+// the epoch for all later ones, so no epoch has to be agreed out of band.
+// The first Encode writes that base in full as an 8-byte CTX64 header; each
+// later call writes a 4-byte CTX relative to it. Decode reads the same
+// stream back. Use separate values for encoding and decoding; the zero
+// value is ready to use.
+type RelativeCodec struct {
+	codec *Codec
+}
+
+// Base returns the stream's base time and whether it has been set.
+func (rc *RelativeCodec) Base() (time.Time, bool) {
+	if rc.codec == nil {
+		return time.Time{}, false
+	}
+	return rc.codec.Epoch(), true
+}
+
+// Encode appends t to dst and returns the extended slice. The first call
+// appends the header, and the base is t at CTX64 precision.
+func (rc *RelativeCodec) Encode(dst []byte, t time.Time) []byte {
+	if rc.codec == nil {
+		base := NewCTX64(t)
+		rc.codec = NewCodec(base.Time())
+		return append(dst, base.Bytes()...)
+	}
+	return rc.codec.Encode(t).AppendBytes(dst)
+}
+
+// Decode reads the next time from the start of b and returns it with the
+// number of bytes consumed. The first call reads the header and returns the
+// base. It returns an error wrapping ErrInvalidLength if b is too short.
+func (rc *RelativeCodec) Decode(b []byte) (time.Time, int, error) {
+	if rc.codec == nil {
+		if len(b) < 8 {
+			return time.Time{}, 0, fmt.Errorf("%w: got %d bytes, want an 8-byte header", ErrInvalidLength, len(b))
+		}
+		base := FromBytes64(b[:8]).Time()
+		rc.codec = NewCodec(base)
+		return base, 8, nil
+	}
+	c, err := ParseBytes(b[:min(len(b), ctxSize)])
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return rc.codec.Decode(c), ctxSize, nil
+}
@@ -0,0 +1,188 @@
+package ctx
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCodec(t *testing.T) {
+	y2k := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	codec := NewCodec(y2k)
+	instant := y2k.Add(83*time.Minute + 250*time.Millisecond)
+
+	near := codec.Encode(instant)
+	if got := codec.Decode(near); !got.Equal(instant) {
+		t.Errorf("Decode(Encode) under 2000 epoch = %v, want %v", got, instant)
+	}
+
+	far := NewCTX(instant)
+	if got := far.Time(); got.Sub(instant).Abs() > tolerance(instant) {
+		t.Errorf("Decode(Encode) under Unix epoch = %v, want about %v", got, instant)
+	}
+	if near == far {
+		t.Errorf("different epochs produced the same encoding %v", near)
+	}
+	if got := NewCodec(time.Unix(0, 0)).Encode(instant); got != far {
+		t.Errorf("Unix-epoch codec = %08X, want NewCTX %08X", uint32(got), uint32(far))
+	}
+
+	if got := codec.Encode(y2k); got != 0 {
+		t.Errorf("Encode(epoch) = %08X, want 0", uint32(got))
+	}
+	if got := codec.Decode(0); !got.Equal(y2k) {
+		t.Errorf("Decode(0) = %v, want %v", got, y2k)
+	}
+}
+
+func TestCodecBeforeEpoch(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	codec := NewCodec(epoch)
+
+	tests := []time.Time{
+		time.Date(2019, 12, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(2019, 12, 31, 12, 0, 0, 0, time.UTC),
+		time.Date(1999, 6, 15, 8, 0, 0, 0, time.UTC),
+	}
+	for _, tm := range tests {
+		ct := codec.Encode(tm)
+		if uint32(ct)&signMask == 0 {
+			t.Errorf("Encode(%v) = %08X, want sign bit set", tm, uint32(ct))
+		}
+		got := codec.Decode(ct)
+		if !got.Before(epoch) {
+			t.Errorf("Decode(Encode(%v)) = %v, want before the epoch", tm, got)
+		}
+		if diff, max := got.Sub(tm).Abs(), tm.Sub(epoch).Abs()>>15; diff > max {
+			t.Errorf("Decode(Encode(%v)) = %v (diff %v, max %v)", tm, got, diff, max)
+		}
+	}
+
+	if got := codec.Encode(epoch); got != 0 {
+		t.Errorf("Encode(epoch) = %08X, want 0", uint32(got))
+	}
+	if got := codec.Decode(CTX(signMask)); !got.Equal(epoch) {
+		t.Errorf("Decode(negative zero) = %v, want the epoch", got)
+	}
+}
+
+func TestEpoch(t *testing.T) {
+	if got := Epoch().Unix(); got != 0 {
+		t.Errorf("Epoch().Unix() = %d, want 0", got)
+	}
+	if got := NewCTX(Epoch()); got != 0 {
+		t.Errorf("NewCTX(Epoch()) = %08X, want 0", uint32(got))
+	}
+
+	y2k := time.Date(2000, 1, 1, 0, 0, 0, 0, time.FixedZone("UTC+1", 3600))
+	if got := NewCodec(y2k).Epoch(); !got.Equal(y2k) || got.Location() != time.UTC {
+		t.Errorf("Codec.Epoch() = %v, want %v in UTC", got, y2k)
+	}
+}
+
+func TestRelativeCodec(t *testing.T) {
+	start := time.Date(2031, 7, 4, 9, 15, 0, 123456789, time.UTC)
+	ts := []time.Time{start}
+	for _, d := range []time.Duration{0, 250 * time.Millisecond, -3 * time.Second, 90 * time.Minute, 36 * time.Hour} {
+		ts = append(ts, start.Add(d))
+	}
+
+	var enc RelativeCodec
+	var stream []byte
+	for _, tm := range ts {
+		stream = enc.Encode(stream, tm)
+	}
+	if want := 8 + (len(ts)-1)*ctxSize; len(stream) != want {
+		t.Fatalf("stream is %d bytes, want %d", len(stream), want)
+	}
+
+	// The decoder knows nothing but the stream itself
+	var dec RelativeCodec
+	if _, ok := dec.Base(); ok {
+		t.Fatal("zero RelativeCodec has a base")
+	}
+	for i, tm := range ts {
+		got, n, err := dec.Decode(stream)
+		if err != nil {
+			t.Fatalf("Decode %d: %v", i, err)
+		}
+		stream = stream[n:]
+		if diff := tm.Sub(got).Abs(); diff >= NewCodec(start).Encode(tm).Resolution() {
+			t.Errorf("Decode %d = %v, want %v", i, got, tm)
+		}
+	}
+	if len(stream) != 0 {
+		t.Errorf("%d bytes left over", len(stream))
+	}
+	if base, _ := dec.Base(); !base.Equal(start) {
+		t.Errorf("Base() = %v, want %v", base, start)
+	}
+
+	if _, _, err := dec.Decode([]byte{1, 2}); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Decode(short record) = %v, want ErrInvalidLength", err)
+	}
+	var fresh RelativeCodec
+	if _, _, err := fresh.Decode([]byte{1, 2, 3, 4}); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Decode(short header) = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestNewCTXRelative(t *testing.T) {
+	delta := 42*time.Minute + 1500*time.Millisecond
+	refs := []time.Time{
+		time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2035, 11, 20, 8, 0, 0, 0, time.FixedZone("", 3600)),
+	}
+
+	var first CTX
+	for i, ref := range refs {
+		c := NewCTXRelative(ref.Add(delta), ref)
+		if i == 0 {
+			first = c
+		} else if c != first {
+			t.Errorf("same delta encoded as %#v and %#v", first, c)
+		}
+		if c != NewCodec(ref).Encode(ref.Add(delta)) {
+			t.Errorf("NewCTXRelative differs from Codec.Encode for ref %v", ref)
+		}
+		got := c.TimeRelative(ref)
+		if diff := ref.Add(delta).Sub(got); diff < 0 || diff >= c.Resolution() {
+			t.Errorf("TimeRelative(%v) = %v, want %v", ref, got, ref.Add(delta))
+		}
+	}
+
+	// The same bytes mean different instants under different references
+	if a, b := first.TimeRelative(refs[0]), first.TimeRelative(refs[1]); a.Equal(b) {
+		t.Errorf("TimeRelative ignores the reference: %v", a)
+	}
+}
+
+func TestRebase(t *testing.T) {
+	e2020 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	e2000 := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	from, to := NewCodec(e2020), NewCodec(e2000)
+
+	for _, d := range []time.Duration{0, 90 * time.Minute, -36 * time.Hour, 3 * 365 * 24 * time.Hour} {
+		instant := e2020.Add(d)
+		c := from.Encode(instant)
+
+		moved := c.Rebase(e2020, e2000)
+		if want := to.Encode(from.Decode(c)); moved != want {
+			t.Errorf("Rebase(%v) = %#v, want %#v", instant, moved, want)
+		}
+		if diff := from.Decode(c).Sub(to.Decode(moved)); diff < 0 || diff >= moved.Resolution() {
+			t.Errorf("Rebase(%v) moved the instant by %v", instant, diff)
+		}
+
+		back := moved.Rebase(e2000, e2020)
+		if diff := from.Decode(c).Sub(from.Decode(back)).Abs(); diff >= moved.Resolution() {
+			t.Errorf("Rebase round trip of %v is off by %v", instant, diff)
+		}
+	}
+
+	// An instant exact under both epochs survives the round trip unchanged
+	c := from.Encode(e2000)
+	if back := c.Rebase(e2020, e2000).Rebase(e2000, e2020); back != c {
+		t.Errorf("round trip of exact instant = %#v, want %#v", back, c)
+	}
+}
@@ -0,0 +1,62 @@
+package ctx
+
+import (
+	"cmp"
+	"time"
+)
+
+// Equal reports whether c and o decode to the same instant. Different scale
+// and extra combinations can encode the same offset, so raw values are not
+// compared.
+func (c CTX) Equal(o CTX) bool {
+	return c.nanos() == o.nanos()
+}
+
+// Before reports whether the instant c is before o.
+func (c CTX) Before(o CTX) bool {
+	return c.nanos() < o.nanos()
+}
+
+// After reports whether the instant c is after o.
+func (c CTX) After(o CTX) bool {
+	return c.nanos() > o.nanos()
+}
+
+// Compare returns -1 if c is before o, +1 if it is after, and 0 if both
+// decode to the same instant. It compares decoded values, since raw integer
+// order is not chronological.
+func (c CTX) Compare(o CTX) int {
+	return cmp.Compare(c.nanos(), o.nanos())
+}
+
+// BytesEqual reports whether a and b are both 4-byte encodings of the same
+// instant. NewCTX output is canonical, but other producers may pick a
+// different scale for the same offset, so the decoded values are compared
+// rather than the bytes.
+func BytesEqual(a, b []byte) bool {
+	ca, err := ParseBytes(a)
+	if err != nil {
+		return false
+	}
+	cb, err := ParseBytes(b)
+	if err != nil {
+		return false
+	}
+	return ca.Equal(cb)
+}
+
+// Within reports whether c lies in the half-open interval [start, end):
+// at or after start and strictly before end. Like Compare it works on
+// decoded instants, and an interval with end at or before start is empty.
+func (c CTX) Within(start, end CTX) bool {
+	n := c.nanos()
+	return start.nanos() <= n && n < end.nanos()
+}
+
+// SkewExceeds reports whether a and b, such as timestamps of the same event
+// from two machines, are more than tolerance apart in either direction.
+// Spans too large for a time.Duration saturate, so they exceed any smaller
+// tolerance.
+func SkewExceeds(a, b CTX, tolerance time.Duration) bool {
+	return a.Sub(b).Abs() > tolerance
+}
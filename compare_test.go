@@ -0,0 +1,154 @@
+package ctx
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	past := NewCTX(epoch.Add(-time.Second))           // milliseconds scale, negative
+	near := NewCTX(epoch.Add(100 * time.Microsecond)) // nanoseconds scale
+	far := NewCTX(epoch.Add(24 * time.Hour))          // seconds scale
+
+	if uint32(past) < uint32(near) {
+		t.Fatalf("raw order unexpectedly chronological: %08X < %08X", uint32(past), uint32(near))
+	}
+
+	ordered := []CTX{past, near, far}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			if got := a.Before(b); got != (i < j) {
+				t.Errorf("%v.Before(%v) = %v", a, b, got)
+			}
+			if got := a.After(b); got != (i > j) {
+				t.Errorf("%v.After(%v) = %v", a, b, got)
+			}
+			if got := a.Equal(b); got != (i == j) {
+				t.Errorf("%v.Equal(%v) = %v", a, b, got)
+			}
+		}
+	}
+
+	// 5µs as 5 microsecond units and as 5000 nanosecond units
+	micro := CTX(scaleMicro<<scaleShift | 5<<valueShift)
+	nano := CTX(5000 << valueShift)
+	if !micro.Equal(nano) || micro.Before(nano) || micro.After(nano) {
+		t.Errorf("%08X and %08X should be equal", uint32(micro), uint32(nano))
+	}
+}
+
+func TestCompareSort(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	var cs []CTX
+	for _, d := range []time.Duration{
+		-365 * 24 * time.Hour, -time.Hour, -time.Second, -time.Microsecond, 0,
+		time.Nanosecond, time.Millisecond, time.Minute, 24 * time.Hour, time.Since(epoch),
+	} {
+		cs = append(cs, NewCTX(epoch.Add(d)))
+	}
+
+	rand.New(rand.NewSource(1)).Shuffle(len(cs), func(i, j int) { cs[i], cs[j] = cs[j], cs[i] })
+	slices.SortFunc(cs, CTX.Compare)
+
+	for i := 1; i < len(cs); i++ {
+		if !cs[i-1].Time().Before(cs[i].Time()) {
+			t.Errorf("sorted[%d] = %v is not before sorted[%d] = %v", i-1, cs[i-1], i, cs[i])
+		}
+	}
+
+	micro := CTX(scaleMicro<<scaleShift | 5<<valueShift)
+	nano := CTX(5000 << valueShift)
+	if got := micro.Compare(nano); got != 0 {
+		t.Errorf("Compare of equal instants = %d, want 0", got)
+	}
+	if got := cs[0].Compare(cs[1]); got != -1 {
+		t.Errorf("Compare(earlier, later) = %d, want -1", got)
+	}
+	if got := cs[1].Compare(cs[0]); got != 1 {
+		t.Errorf("Compare(later, earlier) = %d, want 1", got)
+	}
+}
+
+func TestBytesEqual(t *testing.T) {
+	now := NewCTX(time.Now())
+	micro := CTX(scaleMicro<<scaleShift | 5<<valueShift)
+	nano := CTX(5000 << valueShift)
+
+	tests := []struct {
+		name string
+		a, b []byte
+		want bool
+	}{
+		{"identical", now.Bytes(), now.Bytes(), true},
+		{"different scale", micro.Bytes(), nano.Bytes(), true},
+		{"different instant", now.Bytes(), micro.Bytes(), false},
+		{"short", now.Bytes()[:3], now.Bytes()[:3], false},
+		{"nil", nil, nil, false},
+	}
+	for _, tt := range tests {
+		if got := BytesEqual(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: BytesEqual(% X, % X) = %v, want %v", tt.name, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestWithin(t *testing.T) {
+	base := time.Now()
+	start, end := NewCTX(base), NewCTX(base.Add(24*time.Hour))
+	inside := NewCTX(base.Add(12 * time.Hour))
+
+	tests := []struct {
+		name string
+		c    CTX
+		want bool
+	}{
+		{"before start", start.Prev(), false},
+		{"at start", start, true},
+		{"inside", inside, true},
+		{"last tick", end.Prev(), true},
+		{"at end", end, false},
+		{"after end", end.Next(), false},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Within(start, end); got != tt.want {
+			t.Errorf("%s: %v.Within(%v, %v) = %v, want %v", tt.name, tt.c, start, end, got, tt.want)
+		}
+	}
+	if inside.Within(end, start) {
+		t.Errorf("reversed interval is not empty")
+	}
+}
+
+func TestSkewExceeds(t *testing.T) {
+	a := NewCTX(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	b := NewCTX(time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC))
+	span := b.Sub(a)
+
+	tests := []struct {
+		name      string
+		tolerance time.Duration
+		want      bool
+	}{
+		{"well inside", 3 * time.Hour, false},
+		{"exactly at", span, false},
+		{"just outside", span - 1, true},
+		{"zero", 0, true},
+	}
+	for _, tt := range tests {
+		if got := SkewExceeds(a, b, tt.tolerance); got != tt.want {
+			t.Errorf("%s: SkewExceeds(a, b, %v) = %v, want %v", tt.name, tt.tolerance, got, tt.want)
+		}
+		if got := SkewExceeds(b, a, tt.tolerance); got != tt.want {
+			t.Errorf("%s: SkewExceeds(b, a, %v) = %v, want %v", tt.name, tt.tolerance, got, tt.want)
+		}
+	}
+	if SkewExceeds(a, a, 0) {
+		t.Errorf("SkewExceeds(a, a, 0) = true, want false")
+	}
+	if !SkewExceeds(NewCTX(MinTime()), NewCTX(MaxTime()), time.Hour) {
+		t.Errorf("saturated span does not exceed an hour")
+	}
+}
@@ -0,0 +1,18 @@
+package ctx
+
+// CSV returns c as an RFC 3339 field value for encoding/csv, the same text
+// as MarshalText.
+func (c CTX) CSV() string {
+	return c.String()
+}
+
+// ParseCSV parses a field written by CSV. An empty cell returns the zero
+// CTX, which CSV writes back as the epoch rather than an empty cell.
+func ParseCSV(s string) (CTX, error) {
+	var c CTX
+	if s == "" {
+		return c, nil
+	}
+	err := c.UnmarshalText([]byte(s))
+	return c, err
+}
@@ -0,0 +1,43 @@
+package ctx
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+)
+
+func TestCSV(t *testing.T) {
+	stamps := []CTX{NewCTX(time.Now()), NewCTX(Epoch().Add(-1500 * time.Millisecond)), 0}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for i, c := range stamps {
+		if err := w.Write([]string{string(rune('a' + i)), c.CSV()}); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := w.Write([]string{"empty", ""}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	w.Flush()
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	want := append(stamps, 0)
+	for i, row := range rows {
+		got, err := ParseCSV(row[1])
+		if err != nil {
+			t.Fatalf("ParseCSV(%q): %v", row[1], err)
+		}
+		if got != want[i] {
+			t.Errorf("row %d: ParseCSV(%q) = %v, want %v", i, row[1], got, want[i])
+		}
+	}
+
+	if _, err := ParseCSV("yesterday"); err == nil {
+		t.Errorf("ParseCSV(invalid): expected error")
+	}
+}
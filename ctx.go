@@ -1,110 +1,347 @@
 package ctx
 
 import (
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"math"
+	"math/bits"
 	"time"
 )
 
+// CTX is a compact 4-byte timestamp. It is the package's single timestamp
+// type; the bit layout is scale, sign, value, extra and fraction (see README).
+//
+// As a uint32, CTX works directly with binary.Read and binary.Write, alone or
+// as a struct field; with binary.BigEndian they use the same 4 bytes as Bytes.
 type CTX uint32
 
 const (
-	scaleMask  = 0xC0000000 // 2 bits for scale
-	signMask   = 0x20000000 // 1 bit for sign
-	valueMask  = 0x1FFFF000 // 17 bits for value
-	extraMask  = 0x00000F00 // 4 bits for extra scale
-	fracMask   = 0x000000FF // 8 bits for fraction
-
-	scaleShift  = 30
-	signShift   = 29
-	valueShift  = 12
-	extraShift  = 8
-	fracShift   = 0
+	scaleMask = 0xC0000000 // 2 bits for scale
+	signMask  = 0x20000000 // 1 bit for sign
+	valueMask = 0x1FFFF000 // 17 bits for value
+	extraMask = 0x00000F00 // 4 bits for extra scale
+	fracMask  = 0x000000FF // 8 bits for fraction
+
+	scaleShift = 30
+	signShift  = 29
+	valueShift = 12
+	extraShift = 8
+	fracShift  = 0
+
+	valueBits  = 17
+	valueLimit = 1 << valueBits // 131072 for 17 bits
 
 	fracBits     = 8
 	fracMultiple = 1 << fracBits // 256 for 8 bits
 
+	// straddleMantissa is the mantissa of valueLimit finer units expressed
+	// in the next coarser unit (valueLimit * fracMultiple / 1000, rounded down).
+	straddleMantissa = valueLimit * fracMultiple / 1000
+
 	// Scale values
-	scaleNano  = 0 // nanoseconds
-	scaleMicro = 1 // microseconds
-	scaleMilli = 2 // milliseconds
+	scaleNano   = 0 // nanoseconds
+	scaleMicro  = 1 // microseconds
+	scaleMilli  = 2 // milliseconds
 	scaleSecond = 3 // seconds
 )
 
-var scaleFactors = []float64{
-	1e-9,  // nanoseconds
-	1e-6,  // microseconds
-	1e-3,  // milliseconds
-	1,     // seconds
+// unitNanos holds the length of one value unit in nanoseconds for each
+// exponent (scale + extra). Larger exponents are never needed for an int64
+// nanosecond offset.
+var unitNanos = [...]uint64{1, 1e3, 1e6, 1e9, 1e12, 1e15}
+
+// limitNanos holds, for each exponent, the first magnitude that no longer
+// fits the value field: valueLimit units. The last entry is above any int64.
+var limitNanos = [...]uint64{
+	valueLimit, valueLimit * 1e3, valueLimit * 1e6,
+	valueLimit * 1e9, valueLimit * 1e12, math.MaxUint64,
 }
 
+// NewCTX encodes t. The encoding depends only on the wall-clock instant,
+// not on t's location or monotonic clock reading. Times outside the
+// representable range are clamped to its nearest end; use NewCTXChecked to
+// detect them instead.
 func NewCTX(t time.Time) CTX {
-	// Calculate difference from Unix epoch
-	diff := t.UnixNano()
-	
-	// Find the most appropriate scale
-	var scale, extra uint32
-	absDiff := math.Abs(float64(diff))
-	
-	if absDiff < 1e9 { // < 1 second
-		scale = scaleNano
-	} else if absDiff < 1e12 { // < 1000 seconds
-		scale = scaleMicro
-	} else if absDiff < 1e15 { // < 1M seconds
-		scale = scaleMilli
-	} else {
-		scale = scaleSecond
-	}
-	
-	// Calculate extra scale (powers of 1000)
-	for absDiff >= float64(math.MaxInt32) {
-		absDiff /= 1000
-		extra++
-		if extra >= 15 { // 15 is max value for 4 bits
-			break
-		}
+	return defaultCodec.Encode(t)
+}
+
+// Date encodes the time time.Date would return for the same arguments. Like
+// time.Date it panics if loc is nil.
+func Date(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location) CTX {
+	return NewCTX(time.Date(year, month, day, hour, min, sec, nsec, loc))
+}
+
+// SetTime encodes t into c in place, like *c = NewCTX(t).
+func (c *CTX) SetTime(t time.Time) {
+	*c = NewCTX(t)
+}
+
+// Reset sets c to zero, the encoding of the epoch.
+func (c *CTX) Reset() {
+	*c = 0
+}
+
+// NewCTXChecked is like NewCTX but returns an error wrapping ErrOutOfRange
+// when t falls outside the representable range instead of clamping it.
+func NewCTXChecked(t time.Time) (CTX, error) {
+	return defaultCodec.encodeChecked(t)
+}
+
+// Epoch returns the instant that encodes to zero: the Unix epoch,
+// 1970-01-01T00:00:00Z.
+func Epoch() time.Time {
+	return defaultCodec.Epoch()
+}
+
+// SinceEpoch returns the offset of c from the epoch, c.Time().Sub(Epoch()).
+func (c CTX) SinceEpoch() time.Duration {
+	return time.Duration(c.nanos())
+}
+
+// FromSinceEpoch encodes the instant d after the epoch.
+func FromSinceEpoch(d time.Duration) CTX {
+	return fromNanos(int64(d))
+}
+
+// MinTime returns the earliest instant NewCTX can encode without clamping:
+// the epoch minus the largest int64 nanosecond offset, in 1677.
+func MinTime() time.Time {
+	lo, _ := defaultCodec.bounds()
+	return lo
+}
+
+// MaxTime returns the latest instant NewCTX can encode without clamping: the
+// epoch plus the largest int64 nanosecond offset, in 2262.
+func MaxTime() time.Time {
+	_, hi := defaultCodec.bounds()
+	return hi
+}
+
+// InRange reports whether t lies within [MinTime(), MaxTime()], that is,
+// whether NewCTXChecked accepts it and Clamp leaves it unchanged.
+func InRange(t time.Time) bool {
+	lo, hi := defaultCodec.bounds()
+	return !t.Before(lo) && !t.After(hi)
+}
+
+// Clamp returns MinTime or MaxTime when t falls before or after the
+// representable range, and t otherwise. NewCTX clamps the same way on its
+// own; NewCTXChecked reports exactly the times Clamp would change.
+func Clamp(t time.Time) time.Time {
+	lo, hi := defaultCodec.bounds()
+	switch {
+	case t.Before(lo):
+		return lo
+	case t.After(hi):
+		return hi
 	}
+	return t
+}
 
-	// Convert to selected scale
-	scaleFactor := scaleFactors[scale] * math.Pow(1000, float64(extra))
-	value := float64(diff) * scaleFactor
+// Now returns the current time as a CTX. Only the wall clock is encoded; the
+// monotonic reading carried by time.Now is dropped.
+func Now() CTX {
+	return NewCTX(time.Now())
+}
 
-	// Split into integer and fractional parts
-	intPart := uint32(math.Abs(float64(int64(value))))
-	fracPart := uint32((math.Abs(value) - float64(intPart)) * fracMultiple)
+// NowBytes returns Now and its 4 bytes, for the common stamp-and-write case.
+func NowBytes() (CTX, []byte) {
+	c := Now()
+	return c, c.Bytes()
+}
 
-	// Combine all parts
+// fromNanos encodes a signed nanosecond offset from the epoch, picking the
+// finest unit whose integer part still fits the value field.
+func fromNanos(diff int64) CTX {
 	var result uint32
-	result |= scale << scaleShift
+	mag := uint64(diff)
 	if diff < 0 {
-		result |= 1 << signShift
+		mag = -mag
+		result |= signMask
+	}
+
+	e := 0
+	for mag >= limitNanos[e] {
+		e++
 	}
-	result |= (intPart & 0x1FFFF) << valueShift
-	result |= (extra & 0xF) << extraShift
-	result |= fracPart & 0xFF
+	unit := unitNanos[e]
+
+	// Split into integer and fractional parts
+	value := mag / unit
+	frac := mag % unit * fracMultiple / unit
+
+	// Fill the scale first and spill the rest into extra
+	scale := min(e, scaleSecond)
+	extra := e - scale
 
+	result |= uint32(scale) << scaleShift
+	result |= uint32(value) << valueShift
+	result |= uint32(extra) << extraShift
+	result |= uint32(frac) << fracShift
 	return CTX(result)
 }
 
+// Time returns the instant closest to the epoch that encodes to c, in UTC.
 func (c CTX) Time() time.Time {
-	// Extract components
+	return defaultCodec.Decode(c)
+}
+
+// TimeUTC returns the decoded time in UTC. Time already does so and never
+// depends on time.Local; TimeUTC states the guarantee at the call site.
+func (c CTX) TimeUTC() time.Time {
+	return c.Time().UTC()
+}
+
+// TimeIn returns the decoded time in loc, or in UTC if loc is nil.
+func (c CTX) TimeIn(loc *time.Location) time.Time {
+	if loc == nil {
+		return c.Time()
+	}
+	return c.Time().In(loc)
+}
+
+// exponent returns scale + extra, the power of 1000 that sizes the unit.
+func (c CTX) exponent() int {
 	scale := (uint32(c) & scaleMask) >> scaleShift
-	isNegative := (uint32(c) & signMask) != 0
-	value := (uint32(c) & valueMask) >> valueShift
 	extra := (uint32(c) & extraMask) >> extraShift
-	frac := float64(uint32(c)&fracMask) / fracMultiple
+	return int(scale + extra)
+}
 
-	// Calculate total value
-	scaleFactor := scaleFactors[scale] * math.Pow(1000, float64(extra))
-	totalValue := (float64(value) + frac) / scaleFactor
+// mantissa returns the value and fraction fields as one count of 1/256 units.
+func (c CTX) mantissa() uint64 {
+	value := (uint32(c) & valueMask) >> valueShift
+	frac := (uint32(c) & fracMask) >> fracShift
+	return uint64(value)<<fracBits | uint64(frac)
+}
 
-	if isNegative {
-		totalValue = -totalValue
+// nanos decodes c to a signed nanosecond offset from the epoch. The result is
+// the smallest magnitude that encodes to c, so fromNanos(c.nanos()) == c for
+// every value produced by the encoder. Offsets beyond int64 saturate.
+func (c CTX) nanos() int64 {
+	mag := c.magnitude()
+	if uint32(c)&signMask != 0 {
+		return -int64(mag)
 	}
+	return int64(mag)
+}
 
-	// Convert to time
-	return time.Unix(0, int64(totalValue))
+func (c CTX) magnitude() uint64 {
+	mag, _ := c.decode()
+	return mag
 }
 
+// decode returns the magnitude of c in nanoseconds and whether it fit in an
+// int64 without saturating.
+func (c CTX) decode() (uint64, bool) {
+	e := c.exponent()
+	if e >= len(unitNanos) {
+		return math.MaxInt64, false
+	}
+	unit, m := unitNanos[e], c.mantissa()
+
+	// ceil(m * unit / 256) without overflowing
+	hi, lo := bits.Mul64(m, unit)
+	lo, carry := bits.Add64(lo, fracMultiple-1, 0)
+	hi += carry
+	if hi>>fracBits != 0 {
+		return math.MaxInt64, false
+	}
+	mag := hi<<(64-fracBits) | lo>>fracBits
+	if mag > math.MaxInt64 {
+		return math.MaxInt64, false
+	}
+
+	// The lowest mantissas of a coarser unit overlap the top of the finer
+	// one. The single mantissa straddling the boundary is only ever produced
+	// for offsets at or above it, so decode it to the boundary itself.
+	if e > 0 && m >= straddleMantissa {
+		if limit := valueLimit * unitNanos[e-1]; mag < limit {
+			mag = limit
+		}
+	}
+	return mag, true
+}
+
+// Scale returns the scale field of c: 0 for nanoseconds, 1 for microseconds,
+// 2 for milliseconds and 3 for seconds.
+func (c CTX) Scale() int {
+	return int((uint32(c) & scaleMask) >> scaleShift)
+}
+
+// Resolution returns the tick size of c: the unit given by scale and extra
+// divided by the 256 fraction steps, rounded up to a whole nanosecond.
+// Adjacent encodable instants at this scale are this far apart.
+func (c CTX) Resolution() time.Duration {
+	e := c.exponent()
+	if e >= len(unitNanos) {
+		return math.MaxInt64
+	}
+	return time.Duration((unitNanos[e] + fracMultiple - 1) / fracMultiple)
+}
+
+// IsZero reports whether c is the zero value. FromBytes also returns zero for
+// malformed input, and the epoch itself encodes to zero, so prefer an
+// error-returning decoder when the distinction matters.
+func (c CTX) IsZero() bool {
+	return c == 0
+}
+
+// Valid reports whether the fields of c are consistent: scale and extra add
+// up to a unit the encoder uses, and the offset fits in int64 nanoseconds.
+// Time saturates values that are not valid.
+func (c CTX) Valid() bool {
+	_, ok := c.decode()
+	return ok
+}
+
+// Canonical returns the encoding NewCTX produces for the instant c decodes
+// to. NewCTX always picks the finest unit that fits, so every instant has
+// exactly one canonical form and canonical values can be compared bytewise.
+// Hand-built values may use a coarser scale for the same offset.
+func (c CTX) Canonical() CTX {
+	return fromNanos(c.nanos())
+}
+
+// String returns the decoded time in UTC formatted with time.RFC3339Nano.
+func (c CTX) String() string {
+	return c.Time().UTC().Format(time.RFC3339Nano)
+}
+
+// Format returns the decoded time in UTC formatted with layout, as
+// time.Time.Format does.
+func (c CTX) Format(layout string) string {
+	return c.Time().Format(layout)
+}
+
+// GoString implements fmt.GoStringer for %#v, showing the raw value and the
+// decoded time, e.g. ctx.CTX(0x1E240000 /* 1970-01-01T00:00:00.000123456Z */).
+func (c CTX) GoString() string {
+	return fmt.Sprintf("ctx.CTX(0x%08X /* %s */)", uint32(c), c)
+}
+
+// BytesSaved returns the bytes saved per record by storing a CTX instead of
+// an 8-byte Unix nanosecond timestamp: 4, or 50%.
+func BytesSaved() int {
+	return 8 - len(CTX(0).Bytes())
+}
+
+// Debug returns a multi-line breakdown of the fields of c and the time it
+// decodes to, for troubleshooting.
+func (c CTX) Debug() string {
+	v := uint32(c)
+	return fmt.Sprintf("CTX 0x%08X\nscale: %d\nsign:  %d\nvalue: %d\nextra: %d\nfrac:  %d/256\ntime:  %s",
+		v,
+		(v&scaleMask)>>scaleShift,
+		(v&signMask)>>signShift,
+		(v&valueMask)>>valueShift,
+		(v&extraMask)>>extraShift,
+		(v&fracMask)>>fracShift,
+		c)
+}
+
+// Bytes returns the 4 bytes of c in big-endian order, the package's standard
+// wire form. Decode it with FromBytes or ParseBytes.
 func (c CTX) Bytes() []byte {
 	return []byte{
 		byte(uint32(c) >> 24),
@@ -114,9 +351,136 @@ func (c CTX) Bytes() []byte {
 	}
 }
 
-func FromBytes(b []byte) CTX {
+// BytesLE returns the 4 bytes of c in little-endian order, for formats that
+// store integers that way. Decode it with FromBytesLE, not FromBytes.
+func (c CTX) BytesLE() []byte {
+	return binary.LittleEndian.AppendUint32(make([]byte, 0, 4), uint32(c))
+}
+
+// FromBytesLE decodes the little-endian output of BytesLE. Like FromBytes,
+// it returns zero for a slice of any other length than 4.
+func FromBytesLE(b []byte) CTX {
 	if len(b) != 4 {
 		return 0
 	}
-	return CTX(uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]))
+	return CTX(binary.LittleEndian.Uint32(b))
+}
+
+// Array returns the 4 bytes of c as an array, for fixed-size records that
+// should not hold a slice.
+func (c CTX) Array() [4]byte {
+	return [4]byte{
+		byte(uint32(c) >> 24),
+		byte(uint32(c) >> 16),
+		byte(uint32(c) >> 8),
+		byte(uint32(c)),
+	}
+}
+
+// FromArray decodes the output of Array.
+func FromArray(a [4]byte) CTX {
+	return CTX(uint32(a[0])<<24 | uint32(a[1])<<16 | uint32(a[2])<<8 | uint32(a[3]))
+}
+
+// AppendBytes appends the 4 bytes of c to dst and returns the extended slice.
+func (c CTX) AppendBytes(dst []byte) []byte {
+	return append(dst,
+		byte(uint32(c)>>24),
+		byte(uint32(c)>>16),
+		byte(uint32(c)>>8),
+		byte(uint32(c)),
+	)
+}
+
+// Raw returns the packed integer behind c.
+func (c CTX) Raw() uint32 {
+	return uint32(c)
+}
+
+// FromRaw returns the CTX with packed integer v. It performs no validation;
+// see Valid.
+func FromRaw(v uint32) CTX {
+	return CTX(v)
+}
+
+// AppendVarint appends c to dst as an unsigned varint of its raw value. This
+// gives up fixed-width indexing for size: small offsets in the nanosecond
+// scale take 1 to 4 bytes, but any value in a coarser scale takes 5, one more
+// than Bytes.
+func (c CTX) AppendVarint(dst []byte) []byte {
+	return binary.AppendUvarint(dst, uint64(c))
+}
+
+// ReadVarint decodes a value written by AppendVarint from the start of b and
+// returns it with the number of bytes read.
+func ReadVarint(b []byte) (CTX, int, error) {
+	v, n := binary.Uvarint(b)
+	switch {
+	case n == 0:
+		return 0, 0, fmt.Errorf("%w: truncated varint", ErrInvalidLength)
+	case n < 0 || v > math.MaxUint32:
+		return 0, 0, fmt.Errorf("%w: varint overflows CTX", ErrOutOfRange)
+	}
+	return CTX(v), n, nil
+}
+
+// Errors returned by the decoders, parsers and checked encoders, wrapped with
+// context. Test for them with errors.Is.
+var (
+	// ErrInvalidLength is returned when a byte slice does not have the
+	// length of the encoding being decoded.
+	ErrInvalidLength = errors.New("ctx: invalid length")
+
+	// ErrOutOfRange is returned when a time or value falls outside what the
+	// encoding can represent.
+	ErrOutOfRange = errors.New("ctx: out of range")
+
+	// ErrInvalidFormat is returned when input is not in any accepted form.
+	ErrInvalidFormat = errors.New("ctx: invalid format")
+
+	// ErrLengthMismatch is returned by DiffStreams when the streams hold
+	// different numbers of records.
+	ErrLengthMismatch = errors.New("ctx: streams differ in length")
+)
+
+// FromBytes decodes the 4 bytes produced by Bytes. It returns zero for a
+// slice of any other length; use ParseBytes to detect that case.
+func FromBytes(b []byte) CTX {
+	c, _ := ParseBytes(b)
+	return c
+}
+
+// ParseBytes decodes the 4 bytes produced by Bytes, returning an error
+// wrapping ErrInvalidLength for a slice of any other length.
+func ParseBytes(b []byte) (CTX, error) {
+	if len(b) != 4 {
+		return 0, fmt.Errorf("%w: got %d bytes, want 4", ErrInvalidLength, len(b))
+	}
+	return CTX(uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])), nil
+}
+
+// ParseBytesStrict is like ParseBytes but also rejects, with an error
+// wrapping ErrInvalidFormat, any value NewCTX would not have produced:
+//
+//   - an exponent (scale + extra) past the unit table, or an offset that
+//     does not fit in int64 nanoseconds (see Valid);
+//   - extra set while scale is below seconds, since the encoder fills the
+//     scale field first;
+//   - a unit coarser than needed for the offset, such as 90s stored as 90
+//     seconds rather than 90000 milliseconds;
+//   - the sign bit set on a zero offset.
+//
+// In other words it accepts exactly the canonical encodings.
+func ParseBytesStrict(b []byte) (CTX, error) {
+	c, err := ParseBytes(b)
+	if err != nil {
+		return 0, err
+	}
+	if !c.Valid() {
+		return 0, fmt.Errorf("%w: %#v is outside the representable range", ErrInvalidFormat, c)
+	}
+	if canon := c.Canonical(); c != canon {
+		return 0, fmt.Errorf("%w: %#v is not canonical, want 0x%08X", ErrInvalidFormat, c, uint32(canon))
+	}
+	return c, nil
 }
@@ -0,0 +1,80 @@
+package ctx
+
+import (
+	"fmt"
+	"time"
+)
+
+// CTX48 is a 6-byte timestamp for data outside the range CTX resolves well.
+// It holds a 34-bit two's-complement count of seconds from the Unix epoch and
+// a 10-bit binary fraction of a second, giving a resolution of 1/1024 s
+// (about 977µs) over roughly ±272 years: 1697-10-17 to 2242-03-16 UTC.
+// The top 16 bits of the uint64 are always zero.
+type CTX48 uint64
+
+const (
+	ctx48FracBits = 10
+	ctx48FracMask = 1<<ctx48FracBits - 1
+	ctx48SecBits  = 34
+	ctx48SecMask  = 1<<ctx48SecBits - 1
+	ctx48MinSec   = -1 << (ctx48SecBits - 1)
+	ctx48MaxSec   = 1<<(ctx48SecBits-1) - 1
+)
+
+// NewCTX48 encodes t. Times outside the representable range are clamped to
+// its nearest end.
+func NewCTX48(t time.Time) CTX48 {
+	sec := t.Unix()
+	frac := uint64(t.Nanosecond()) << ctx48FracBits / 1e9
+	switch {
+	case sec < ctx48MinSec:
+		sec, frac = ctx48MinSec, 0
+	case sec > ctx48MaxSec:
+		sec, frac = ctx48MaxSec, ctx48FracMask
+	}
+	return CTX48(uint64(sec)&ctx48SecMask<<ctx48FracBits | frac)
+}
+
+// Time returns the earliest instant that encodes to c, in UTC.
+func (c CTX48) Time() time.Time {
+	sec, frac := c.fields()
+	nsec := (frac*1e9 + ctx48FracMask) >> ctx48FracBits
+	return time.Unix(sec, int64(nsec)).UTC()
+}
+
+// fields returns the seconds and fraction fields of c.
+func (c CTX48) fields() (sec int64, frac uint64) {
+	// Sign-extend the seconds field from bit 43
+	sec = int64(uint64(c)<<(64-ctx48FracBits-ctx48SecBits)) >> (64 - ctx48SecBits)
+	return sec, uint64(c) & ctx48FracMask
+}
+
+// Debug returns a multi-line breakdown of the fields of c and the time it
+// decodes to, for troubleshooting.
+func (c CTX48) Debug() string {
+	sec, frac := c.fields()
+	return fmt.Sprintf("CTX48 0x%012X\nseconds: %d\nfrac:    %d/1024\ntime:    %s",
+		uint64(c), sec, frac, c.Time().Format(time.RFC3339Nano))
+}
+
+// Bytes returns the 6 bytes of c in big-endian order.
+func (c CTX48) Bytes() []byte {
+	return []byte{
+		byte(uint64(c) >> 40),
+		byte(uint64(c) >> 32),
+		byte(uint64(c) >> 24),
+		byte(uint64(c) >> 16),
+		byte(uint64(c) >> 8),
+		byte(uint64(c)),
+	}
+}
+
+// FromBytes48 decodes the 6 bytes produced by CTX48.Bytes. It returns zero
+// for a slice of any other length.
+func FromBytes48(b []byte) CTX48 {
+	if len(b) != 6 {
+		return 0
+	}
+	return CTX48(uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 |
+		uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5]))
+}
@@ -0,0 +1,92 @@
+package ctx
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCTX48(t *testing.T) {
+	const resolution = time.Second / 1024
+
+	tests := []struct {
+		name string
+		time time.Time
+	}{
+		{"epoch", time.Unix(0, 0)},
+		{"now", time.Now()},
+		{"pre_epoch", time.Date(1900, 6, 15, 12, 30, 0, 123456789, time.UTC)},
+		{"earliest", time.Unix(ctx48MinSec, 0)},
+		{"far_past", time.Date(1700, 1, 1, 0, 0, 0, 999999999, time.UTC)},
+		{"far_future", time.Date(2242, 1, 1, 0, 0, 0, 500000000, time.UTC)},
+		{"latest", time.Unix(ctx48MaxSec, 999999999)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := NewCTX48(tt.time)
+			b := ct.Bytes()
+			if len(b) != 6 {
+				t.Fatalf("Bytes() has %d bytes, want 6", len(b))
+			}
+			got := FromBytes48(b).Time()
+			if diff := tt.time.Sub(got); diff < 0 || diff >= resolution {
+				t.Errorf("round-trip = %v, want %v (diff %v)", got, tt.time, diff)
+			}
+		})
+	}
+}
+
+func TestCTX48Resolution(t *testing.T) {
+	// The documented resolution is 1/1024 s: every step of the fraction field
+	// is distinguishable, and no finer detail survives.
+	base := time.Unix(1700000000, 0)
+	seen := make(map[CTX48]bool)
+	for i := 0; i < 1024; i++ {
+		step := base.Add(time.Duration(i) * time.Second / 1024)
+		ct := NewCTX48(step.Add(time.Microsecond))
+		if seen[ct] {
+			t.Fatalf("fraction step %d collides with an earlier step", i)
+		}
+		seen[ct] = true
+		if NewCTX48(step.Add(time.Second/1024-1)) != ct {
+			t.Errorf("instants within fraction step %d encode differently", i)
+		}
+	}
+	if got := NewCTX48(base.Add(time.Microsecond)); got != NewCTX48(base) {
+		t.Errorf("CTX48 resolves a single microsecond, finer than documented")
+	}
+}
+
+func TestCTX48Clamp(t *testing.T) {
+	if got, want := NewCTX48(time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC)).Time(), time.Unix(ctx48MinSec, 0); !got.Equal(want) {
+		t.Errorf("below range = %v, want %v", got, want)
+	}
+	if got, want := NewCTX48(time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC)).Time(), time.Unix(ctx48MaxSec, 0); !got.Truncate(time.Second).Equal(want) {
+		t.Errorf("above range = %v, want %v", got, want)
+	}
+	if got := FromBytes48([]byte{1, 2, 3}); got != 0 {
+		t.Errorf("FromBytes48(short) = %X, want 0", uint64(got))
+	}
+}
+
+func TestCTX48Debug(t *testing.T) {
+	c := NewCTX48(time.Unix(-2, 5e8))
+	got := c.Debug()
+	for _, want := range []string{"seconds: -2", "frac:    512/1024", "time:    1969-12-31T23:59:58.5Z"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Debug() = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestCTX48MaxFraction(t *testing.T) {
+	c := CTX48(5<<ctx48FracBits | ctx48FracMask)
+	got := c.Time()
+	if got.Unix() != 5 || got.Nanosecond() >= 1e9 {
+		t.Errorf("Time() = %v (%d s, %d ns), want within second 5", got, got.Unix(), got.Nanosecond())
+	}
+	if want := time.Second - time.Second/1024; time.Duration(got.Nanosecond()) < want {
+		t.Errorf("Nanosecond() = %d, want at least %d", got.Nanosecond(), want)
+	}
+}
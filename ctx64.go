@@ -0,0 +1,93 @@
+package ctx
+
+import (
+	"fmt"
+	"time"
+)
+
+// CTX64 is an 8-byte timestamp with full nanosecond fidelity. It holds the
+// same 34-bit two's-complement seconds as CTX48 in the top bits and the
+// nanosecond within the second in the low 30 bits, so times between
+// 1697-10-17 and 2242-03-16 UTC round-trip exactly.
+type CTX64 uint64
+
+const (
+	ctx64NanoBits = 30
+	ctx64NanoMask = 1<<ctx64NanoBits - 1
+)
+
+// NewCTX64 encodes t. Times outside the representable range are clamped to
+// its nearest end.
+func NewCTX64(t time.Time) CTX64 {
+	sec, nsec := t.Unix(), uint64(t.Nanosecond())
+	switch {
+	case sec < ctx48MinSec:
+		sec, nsec = ctx48MinSec, 0
+	case sec > ctx48MaxSec:
+		sec, nsec = ctx48MaxSec, 999999999
+	}
+	return CTX64(uint64(sec)<<ctx64NanoBits | nsec)
+}
+
+// Time returns the instant c represents, in UTC. The 30-bit nanosecond
+// field can hold values up to 1<<30-1 in input not produced by NewCTX64;
+// whole seconds in it carry into the seconds field.
+func (c CTX64) Time() time.Time {
+	sec, nsec := c.fields()
+	if nsec >= 1e9 {
+		sec, nsec = sec+1, nsec-1e9
+	}
+	return time.Unix(sec, nsec).UTC()
+}
+
+// fields returns the seconds and nanoseconds fields of c.
+func (c CTX64) fields() (sec, nsec int64) {
+	return int64(c) >> ctx64NanoBits, int64(uint64(c) & ctx64NanoMask)
+}
+
+// Debug returns a multi-line breakdown of the fields of c and the time it
+// decodes to, for troubleshooting.
+func (c CTX64) Debug() string {
+	sec, nsec := c.fields()
+	return fmt.Sprintf("CTX64 0x%016X\nseconds: %d\nnanos:   %d\ntime:    %s",
+		uint64(c), sec, nsec, c.Time().Format(time.RFC3339Nano))
+}
+
+// Bytes returns the 8 bytes of c in big-endian order.
+func (c CTX64) Bytes() []byte {
+	return []byte{
+		byte(uint64(c) >> 56),
+		byte(uint64(c) >> 48),
+		byte(uint64(c) >> 40),
+		byte(uint64(c) >> 32),
+		byte(uint64(c) >> 24),
+		byte(uint64(c) >> 16),
+		byte(uint64(c) >> 8),
+		byte(uint64(c)),
+	}
+}
+
+// FromBytes64 decodes the 8 bytes produced by CTX64.Bytes. It returns zero
+// for a slice of any other length.
+func FromBytes64(b []byte) CTX64 {
+	if len(b) != 8 {
+		return 0
+	}
+	return CTX64(uint64(b[0])<<56 | uint64(b[1])<<48 | uint64(b[2])<<40 | uint64(b[3])<<32 |
+		uint64(b[4])<<24 | uint64(b[5])<<16 | uint64(b[6])<<8 | uint64(b[7]))
+}
+
+// ToCTX64 widens c to a CTX64 holding the instant c decodes to. Only the
+// representation grows: precision discarded when c was encoded cannot be
+// recovered. Instants outside the CTX64 range are clamped.
+func (c CTX) ToCTX64() CTX64 {
+	return NewCTX64(c.Time())
+}
+
+// ToCTX narrows c to a CTX and reports the precision lost: the absolute
+// difference between the instant c holds and the one the CTX decodes to, as
+// returned by PrecisionLoss.
+func (c CTX64) ToCTX() (CTX, time.Duration) {
+	t := c.Time()
+	return NewCTX(t), PrecisionLoss(t)
+}
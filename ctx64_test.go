@@ -0,0 +1,97 @@
+package ctx
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCTX64(t *testing.T) {
+	tests := []struct {
+		name string
+		time time.Time
+	}{
+		{"epoch", time.Unix(0, 0)},
+		{"now", time.Now().Round(0)},
+		{"max_nanos", time.Date(2024, 5, 17, 8, 30, 15, 999999999, time.UTC)},
+		{"pre_epoch", time.Date(1950, 1, 1, 0, 0, 0, 1, time.UTC)},
+		{"earliest", time.Unix(ctx48MinSec, 0)},
+		{"latest", time.Unix(ctx48MaxSec, 999999999)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := NewCTX64(tt.time)
+			b := ct.Bytes()
+			if len(b) != 8 {
+				t.Fatalf("Bytes() has %d bytes, want 8", len(b))
+			}
+			if got := FromBytes64(b).Time(); !got.Equal(tt.time) {
+				t.Errorf("round-trip = %v, want %v", got, tt.time)
+			}
+		})
+	}
+
+	if got, want := NewCTX64(time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)).Time(), time.Unix(ctx48MaxSec, 999999999); !got.Equal(want) {
+		t.Errorf("above range = %v, want %v", got, want)
+	}
+	if got := FromBytes64([]byte{1, 2, 3, 4, 5}); got != 0 {
+		t.Errorf("FromBytes64(short) = %X, want 0", uint64(got))
+	}
+}
+
+func TestCTX64Debug(t *testing.T) {
+	c := NewCTX64(time.Unix(1700000000, 123456789))
+	got := c.Debug()
+	for _, want := range []string{"seconds: 1700000000", "nanos:   123456789", "time:    2023-11-14T22:13:20.123456789Z"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Debug() = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestToCTX64(t *testing.T) {
+	for _, tm := range append(sampleTimes(100), time.Unix(0, 0).Add(-1234567*time.Microsecond)) {
+		ct := NewCTX(tm)
+		wide := ct.ToCTX64()
+		if !wide.Time().Equal(ct.Time()) {
+			t.Errorf("ToCTX64(%v) = %v, want the same instant", ct, wide.Time())
+		}
+		if diff := wide.Time().Sub(tm).Abs(); diff >= ct.Resolution() {
+			t.Errorf("ToCTX64(NewCTX(%v)) is %v away, more than the CTX resolution %v", tm, diff, ct.Resolution())
+		}
+	}
+}
+
+func TestCTX64ToCTX(t *testing.T) {
+	for _, tm := range append(sampleTimes(100), time.Unix(0, 0).Add(-1234567891)) {
+		wide := NewCTX64(tm)
+		narrow, loss := wide.ToCTX()
+		if want := NewCTX(tm); narrow != want {
+			t.Errorf("ToCTX(%v) = %v, want %v", wide.Time(), narrow, want)
+		}
+		if actual := wide.Time().Sub(narrow.Time()).Abs(); loss != actual {
+			t.Errorf("ToCTX(%v) reports loss %v, actual difference %v", wide.Time(), loss, actual)
+		}
+		if loss >= narrow.Resolution() {
+			t.Errorf("ToCTX(%v) loss %v exceeds resolution %v", wide.Time(), loss, narrow.Resolution())
+		}
+	}
+
+	// Exactly representable instants lose nothing
+	if _, loss := NewCTX64(time.Unix(90, 0)).ToCTX(); loss != 0 {
+		t.Errorf("ToCTX(90s) loss = %v, want 0", loss)
+	}
+}
+
+func TestCTX64NanosOverflow(t *testing.T) {
+	// The largest nanosecond field is 73741823ns past a whole second
+	c := CTX64(5<<ctx64NanoBits | ctx64NanoMask)
+	got := c.Time()
+	if want := time.Unix(6, 73741823); !got.Equal(want) {
+		t.Errorf("Time() = %v, want %v", got, want)
+	}
+	if ns := got.Nanosecond(); ns < 0 || ns >= 1e9 {
+		t.Errorf("Nanosecond() = %d, out of range", ns)
+	}
+}
@@ -0,0 +1,30 @@
+package ctx
+
+import "time"
+
+// CTXDuration is a compact 4-byte duration using the same layout as CTX:
+// the sign, scale and extra fields pick a unit fine enough for the
+// magnitude, so short durations keep nanosecond-level precision while long
+// ones stay within 1/256 of a unit.
+type CTXDuration uint32
+
+// NewCTXDuration encodes d, truncating toward zero.
+func NewCTXDuration(d time.Duration) CTXDuration {
+	return CTXDuration(fromNanos(int64(d)))
+}
+
+// Duration returns the shortest duration that encodes to c.
+func (c CTXDuration) Duration() time.Duration {
+	return time.Duration(CTX(c).nanos())
+}
+
+// Bytes returns the 4 bytes of c in big-endian order.
+func (c CTXDuration) Bytes() []byte {
+	return CTX(c).Bytes()
+}
+
+// FromDurationBytes decodes the 4 bytes produced by CTXDuration.Bytes. It
+// returns zero for a slice of any other length.
+func FromDurationBytes(b []byte) CTXDuration {
+	return CTXDuration(FromBytes(b))
+}
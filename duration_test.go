@@ -0,0 +1,35 @@
+package ctx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCTXDuration(t *testing.T) {
+	for _, d := range []time.Duration{
+		0,
+		time.Microsecond,
+		1234 * time.Microsecond,
+		time.Millisecond,
+		42 * time.Millisecond,
+		time.Second,
+		90 * time.Second,
+		time.Minute + 17*time.Millisecond,
+		time.Hour,
+		-250 * time.Millisecond,
+	} {
+		c := NewCTXDuration(d)
+		got := FromDurationBytes(c.Bytes()).Duration()
+		res := CTX(c).Resolution()
+		if diff := (d - got).Abs(); got.Abs() > d.Abs() || diff >= res {
+			t.Errorf("NewCTXDuration(%v) decodes to %v, resolution %v", d, got, res)
+		}
+		if NewCTXDuration(got) != c {
+			t.Errorf("NewCTXDuration(%v) is not stable: %08X", got, uint32(c))
+		}
+	}
+
+	if got := FromDurationBytes([]byte{1}); got != 0 {
+		t.Errorf("FromDurationBytes(short) = %08X, want 0", uint32(got))
+	}
+}
@@ -0,0 +1,92 @@
+package ctx
+
+import (
+	"fmt"
+	"time"
+)
+
+// FixedCodec generalizes the CTX48 and CTX64 layout: a two's-complement
+// count of whole seconds from an epoch above a count of sub-second ticks,
+// with caller-chosen widths and tick size. Values are packed into the low
+// bits of a uint64.
+type FixedCodec struct {
+	epoch      time.Time
+	resolution time.Duration
+	secBits    int
+	fracBits   int
+}
+
+// NewFixedCodec returns a FixedCodec with secondsBits bits of seconds and
+// fracBits bits counting ticks of resolution within each second. The
+// resolution must divide one second evenly and a second's worth of ticks
+// must fit in fracBits. NewFixedCodec(time.Nanosecond, 34, 30, Epoch())
+// reproduces CTX64.
+func NewFixedCodec(resolution time.Duration, secondsBits, fracBits int, epoch time.Time) (*FixedCodec, error) {
+	switch {
+	case resolution <= 0 || time.Second%resolution != 0:
+		return nil, fmt.Errorf("ctx: resolution %v does not divide one second", resolution)
+	case secondsBits < 1 || fracBits < 0 || secondsBits+fracBits > 64:
+		return nil, fmt.Errorf("ctx: %d seconds bits and %d fraction bits do not fit in 64 bits",
+			secondsBits, fracBits)
+	case secondsBits > 62:
+		return nil, fmt.Errorf("ctx: %d seconds bits exceed the 62 supported", secondsBits)
+	case uint64(time.Second/resolution) > 1<<fracBits:
+		return nil, fmt.Errorf("ctx: %d fraction bits cannot count %d ticks of %v",
+			fracBits, time.Second/resolution, resolution)
+	}
+	return &FixedCodec{epoch: epoch.UTC(), resolution: resolution, secBits: secondsBits, fracBits: fracBits}, nil
+}
+
+// Size returns the number of bytes needed to store an encoded value.
+func (fc *FixedCodec) Size() int {
+	return (fc.secBits + fc.fracBits + 7) / 8
+}
+
+// Resolution returns the tick size of the codec.
+func (fc *FixedCodec) Resolution() time.Duration {
+	return fc.resolution
+}
+
+// Range returns the earliest and latest instants the codec can encode.
+func (fc *FixedCodec) Range() (time.Time, time.Time) {
+	lo, hi := fc.secRange()
+	return fc.at(lo, 0), fc.at(hi, int64(time.Second/fc.resolution)-1)
+}
+
+// at returns the instant sec seconds and ticks ticks after the epoch.
+func (fc *FixedCodec) at(sec, ticks int64) time.Time {
+	return time.Unix(fc.epoch.Unix()+sec, int64(fc.epoch.Nanosecond())+ticks*int64(fc.resolution)).UTC()
+}
+
+func (fc *FixedCodec) secRange() (int64, int64) {
+	return -1 << (fc.secBits - 1), 1<<(fc.secBits-1) - 1
+}
+
+// Encode encodes t, truncating to the resolution. Times outside the range
+// are clamped to its nearest end.
+func (fc *FixedCodec) Encode(t time.Time) uint64 {
+	sec := t.Unix() - fc.epoch.Unix()
+	nsec := int64(t.Nanosecond() - fc.epoch.Nanosecond())
+	if nsec < 0 {
+		sec, nsec = sec-1, nsec+1e9
+	}
+	ticks := uint64(nsec / int64(fc.resolution))
+
+	lo, hi := fc.secRange()
+	switch {
+	case sec < lo:
+		sec, ticks = lo, 0
+	case sec > hi:
+		sec, ticks = hi, uint64(time.Second/fc.resolution)-1
+	}
+	secMask := uint64(1)<<fc.secBits - 1
+	return uint64(sec)&secMask<<fc.fracBits | ticks
+}
+
+// Decode returns the instant v represents, in UTC.
+func (fc *FixedCodec) Decode(v uint64) time.Time {
+	// Sign-extend the seconds field
+	sec := int64(v<<(64-fc.fracBits-fc.secBits)) >> (64 - fc.secBits)
+	ticks := int64(v & (1<<fc.fracBits - 1))
+	return fc.at(sec, ticks)
+}
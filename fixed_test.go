@@ -0,0 +1,90 @@
+package ctx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFixedCodec(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		resolution time.Duration
+		secBits    int
+		fracBits   int
+		size       int
+		lo, hi     time.Time
+	}{
+		{"millis_42", time.Millisecond, 32, 10, 6,
+			epoch.Add(-(1 << 31) * time.Second), epoch.Add((1<<31)*time.Second - time.Millisecond)},
+		{"micros_40", time.Microsecond, 20, 20, 5,
+			epoch.Add(-(1 << 19) * time.Second), epoch.Add((1<<19)*time.Second - time.Microsecond)},
+		{"seconds_62", time.Second, 62, 0, 8,
+			time.Unix(epoch.Unix()-1<<61, 0), time.Unix(epoch.Unix()+1<<61-1, 0)},
+		{"seconds_24", time.Second, 24, 0, 3,
+			epoch.Add(-(1 << 23) * time.Second), epoch.Add((1<<23 - 1) * time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc, err := NewFixedCodec(tt.resolution, tt.secBits, tt.fracBits, epoch)
+			if err != nil {
+				t.Fatalf("NewFixedCodec: %v", err)
+			}
+			if got := fc.Size(); got != tt.size {
+				t.Errorf("Size() = %d, want %d", got, tt.size)
+			}
+			if lo, hi := fc.Range(); !lo.Equal(tt.lo) || !hi.Equal(tt.hi) {
+				t.Errorf("Range() = %v, %v; want %v, %v", lo, hi, tt.lo, tt.hi)
+			}
+
+			for _, tm := range []time.Time{epoch, epoch.Add(-1), tt.lo, tt.hi,
+				epoch.Add(4*24*time.Hour + 1234567891), epoch.Add(-3*time.Hour - 987654321)} {
+				v := fc.Encode(tm)
+				if v>>(tt.secBits+tt.fracBits) != 0 {
+					t.Errorf("Encode(%v) = %X overflows %d bits", tm, v, tt.secBits+tt.fracBits)
+				}
+				if diff := tm.Sub(fc.Decode(v)); diff < 0 || diff >= tt.resolution {
+					t.Errorf("Decode(Encode(%v)) = %v, off by %v", tm, fc.Decode(v), diff)
+				}
+			}
+
+			if got := fc.Decode(fc.Encode(tt.hi.Add(time.Hour))); !got.Equal(tt.hi) {
+				t.Errorf("above range clamps to %v, want %v", got, tt.hi)
+			}
+			if got := fc.Decode(fc.Encode(tt.lo.Add(-time.Hour))); !got.Equal(tt.lo) {
+				t.Errorf("below range clamps to %v, want %v", got, tt.lo)
+			}
+		})
+	}
+}
+
+func TestFixedCodecMatchesCTX64(t *testing.T) {
+	fc, err := NewFixedCodec(time.Nanosecond, 34, 30, Epoch())
+	if err != nil {
+		t.Fatalf("NewFixedCodec: %v", err)
+	}
+	for _, tm := range append(sampleTimes(50), time.Date(1900, 6, 15, 12, 30, 0, 123456789, time.UTC)) {
+		if got, want := fc.Encode(tm), uint64(NewCTX64(tm)); got != want {
+			t.Errorf("Encode(%v) = %X, want CTX64 %X", tm, got, want)
+		}
+	}
+}
+
+func TestNewFixedCodecInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		resolution        time.Duration
+		secBits, fracBits int
+	}{
+		{0, 32, 10},
+		{7 * time.Millisecond, 32, 10}, // does not divide a second
+		{time.Millisecond, 32, 9},      // 1000 ticks need 10 bits
+		{time.Nanosecond, 40, 30},      // 70 bits
+		{time.Second, 63, 0},
+		{time.Second, 0, 8},
+	} {
+		if _, err := NewFixedCodec(tt.resolution, tt.secBits, tt.fracBits, Epoch()); err == nil {
+			t.Errorf("NewFixedCodec(%v, %d, %d): expected error", tt.resolution, tt.secBits, tt.fracBits)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package ctx
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Set implements flag.Value together with String. It accepts an RFC 3339
+// timestamp or an integer number of Unix seconds.
+func (c *CTX) Set(s string) error {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		*c = NewCTX(t)
+		return nil
+	}
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*c = NewCTX(time.Unix(sec, 0))
+		return nil
+	}
+	return fmt.Errorf("%w: time %q: want RFC 3339 or Unix seconds", ErrInvalidFormat, s)
+}
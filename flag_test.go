@@ -0,0 +1,42 @@
+package ctx
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestFlag(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want time.Time
+	}{
+		{"2024-01-02T03:04:05Z", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{"2024-01-02T08:34:05+05:30", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{"1700000000", time.Unix(1700000000, 0)},
+		{"-86400", time.Unix(-86400, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			var since CTX
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.Var(&since, "since", "only show events after this time")
+			if err := fs.Parse([]string{"--since", tt.arg}); err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if want := NewCTX(tt.want); since != want {
+				t.Errorf("since = %v, want %v", since, want)
+			}
+		})
+	}
+
+	var since CTX
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&since, "since", "")
+	if err := fs.Parse([]string{"--since", "last tuesday"}); err == nil {
+		t.Error("Parse(invalid): expected error")
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/HoyoGey/ctx
 
 go 1.21
+
+require google.golang.org/protobuf v1.34.2
@@ -0,0 +1,22 @@
+package ctx
+
+import "io"
+
+// WriteTo implements io.WriterTo, writing the 4 bytes of c to w.
+func (c CTX) WriteTo(w io.Writer) (int64, error) {
+	var buf [4]byte
+	n, err := w.Write(c.AppendBytes(buf[:0]))
+	return int64(n), err
+}
+
+// ReadFrom implements io.ReaderFrom, reading exactly 4 bytes from r. A read
+// that stops part-way returns io.ErrUnexpectedEOF and leaves c unchanged.
+func (c *CTX) ReadFrom(r io.Reader) (int64, error) {
+	var buf [4]byte
+	n, err := io.ReadFull(r, buf[:])
+	if err != nil {
+		return int64(n), err
+	}
+	*c = FromBytes(buf[:])
+	return int64(n), nil
+}
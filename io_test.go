@@ -0,0 +1,52 @@
+package ctx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestWriteToReadFrom(t *testing.T) {
+	var buf bytes.Buffer
+	want := []CTX{NewCTX(time.Now()), NewCTX(time.Unix(0, 0)), NewCTX(time.Unix(-86400, 0))}
+	for _, ct := range want {
+		n, err := ct.WriteTo(&buf)
+		if err != nil || n != 4 {
+			t.Fatalf("WriteTo = %d, %v; want 4, nil", n, err)
+		}
+	}
+
+	for _, w := range want {
+		var ct CTX
+		n, err := ct.ReadFrom(&buf)
+		if err != nil || n != 4 {
+			t.Fatalf("ReadFrom = %d, %v; want 4, nil", n, err)
+		}
+		if ct != w {
+			t.Errorf("ReadFrom = %v, want %v", ct, w)
+		}
+	}
+
+	var ct CTX
+	if _, err := ct.ReadFrom(&buf); err != io.EOF {
+		t.Errorf("ReadFrom on empty reader: err = %v, want io.EOF", err)
+	}
+}
+
+func TestReadFromTruncated(t *testing.T) {
+	ct := CTX(0x12345678)
+	r := io.LimitReader(bytes.NewReader(NewCTX(time.Now()).Bytes()), 3)
+
+	n, err := ct.ReadFrom(r)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("err = %v, want io.ErrUnexpectedEOF", err)
+	}
+	if n != 3 {
+		t.Errorf("n = %d, want 3", n)
+	}
+	if ct != 0x12345678 {
+		t.Errorf("value changed to %08X on short read", uint32(ct))
+	}
+}
@@ -0,0 +1,115 @@
+package ctx
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns the same
+// bytes as Bytes.
+func (c CTX) MarshalBinary() ([]byte, error) {
+	return c.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Unlike FromBytes it
+// rejects input that is not exactly 4 bytes long.
+func (c *CTX) UnmarshalBinary(b []byte) error {
+	v, err := ParseBytes(b)
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder. The gob wire form is the 4 bytes of
+// Bytes, the same as MarshalBinary, and is kept stable across releases.
+func (c CTX) GobEncode() ([]byte, error) {
+	return c.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder, accepting exactly 4 bytes.
+func (c *CTX) GobDecode(b []byte) error {
+	return c.UnmarshalBinary(b)
+}
+
+// MarshalJSON implements json.Marshaler. The value is written as a quoted
+// RFC 3339 string.
+func (c CTX) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + c.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts an RFC 3339 string,
+// or a bare number holding the raw uint32 value for backward compatibility.
+// A JSON null leaves the value unchanged.
+func (c *CTX) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		return c.UnmarshalText([]byte(s))
+	}
+	v, err := strconv.ParseUint(string(b), 10, 32)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+	*c = CTX(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the same RFC 3339
+// form as String.
+func (c CTX) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing an RFC 3339
+// timestamp.
+func (c *CTX) UnmarshalText(b []byte) error {
+	t, err := time.Parse(time.RFC3339, string(b))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+	*c = NewCTX(t)
+	return nil
+}
+
+// Hex returns the 4 bytes of c as 8 lowercase hex digits.
+func (c CTX) Hex() string {
+	return hex.EncodeToString(c.Bytes())
+}
+
+// ParseHex decodes the output of Hex. Either letter case is accepted.
+func ParseHex(s string) (CTX, error) {
+	if len(s) != 2*ctxSize {
+		return 0, fmt.Errorf("%w: got %d hex digits, want %d", ErrInvalidLength, len(s), 2*ctxSize)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+	return FromBytes(b), nil
+}
+
+// Base64 returns the 4 bytes of c in unpadded URL-safe base64
+// (base64.RawURLEncoding), which is 6 characters long.
+func (c CTX) Base64() string {
+	return base64.RawURLEncoding.EncodeToString(c.Bytes())
+}
+
+// ParseBase64 decodes the output of Base64.
+func ParseBase64(s string) (CTX, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+	return ParseBytes(b)
+}
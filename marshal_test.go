@@ -0,0 +1,218 @@
+package ctx
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBinaryMarshal(t *testing.T) {
+	ct := NewCTX(time.Now())
+
+	b, err := ct.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	if !bytes.Equal(b, ct.Bytes()) {
+		t.Errorf("MarshalBinary = % X, want % X", b, ct.Bytes())
+	}
+
+	var got CTX
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if got != ct {
+		t.Errorf("UnmarshalBinary = %08X, want %08X", uint32(got), uint32(ct))
+	}
+
+	for _, n := range []int{0, 3, 5} {
+		if err := got.UnmarshalBinary(make([]byte, n)); err == nil {
+			t.Errorf("UnmarshalBinary(%d bytes): expected error", n)
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type record struct {
+		ID   int
+		Time CTX
+	}
+	in := record{ID: 7, Time: NewCTX(time.Now())}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if out != in {
+		t.Errorf("gob round-trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestGobWireForm(t *testing.T) {
+	ct := CTX(0x1E240000)
+	b, err := ct.GobEncode()
+	if err != nil || !bytes.Equal(b, ct.Bytes()) {
+		t.Fatalf("GobEncode() = % X, %v; want % X", b, err, ct.Bytes())
+	}
+	var got CTX
+	if err := got.GobDecode(b); err != nil || got != ct {
+		t.Errorf("GobDecode(% X) = %v, %v; want %v", b, got, err, ct)
+	}
+	if err := got.GobDecode(b[:3]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("GobDecode(short) error = %v, want ErrInvalidLength", err)
+	}
+
+	// After the type description, each value is the 4 bytes behind a
+	// length prefix, plus gob's message framing
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(ct); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	buf.Reset() // drop the one-time type description
+	if err := enc.Encode(ct); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if want := append([]byte{4}, ct.Bytes()...); !bytes.HasSuffix(buf.Bytes(), want) {
+		t.Errorf("gob value = % X, want it to end with % X", buf.Bytes(), want)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type event struct {
+		At CTX `json:"at"`
+	}
+	in := event{At: NewCTX(time.Unix(0, 0))}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got, want := string(b), `{"at":"1970-01-01T00:00:00Z"}`; got != want {
+		t.Errorf("Marshal = %s, want %s", got, want)
+	}
+
+	var out event
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Unmarshal string: %v", err)
+	}
+	if out != in {
+		t.Errorf("string round-trip = %08X, want %08X", uint32(out.At), uint32(in.At))
+	}
+
+	raw := CTX(0xC0012345)
+	if err := json.Unmarshal([]byte(`{"at":3221300037}`), &out); err != nil {
+		t.Fatalf("Unmarshal number: %v", err)
+	}
+	if out.At != raw {
+		t.Errorf("numeric decode = %08X, want %08X", uint32(out.At), uint32(raw))
+	}
+
+	for _, bad := range []string{`"yesterday"`, `-1`, `4294967296`, `true`} {
+		var c CTX
+		if err := json.Unmarshal([]byte(bad), &c); err == nil {
+			t.Errorf("Unmarshal(%s): expected error", bad)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	ct := NewCTX(time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC))
+
+	b, err := ct.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText: %v", err)
+	}
+	if string(b) != ct.String() {
+		t.Errorf("MarshalText = %s, want %s", b, ct.String())
+	}
+
+	var got CTX
+	if err := got.UnmarshalText(b); err != nil {
+		t.Fatalf("UnmarshalText: %v", err)
+	}
+	if got != ct {
+		t.Errorf("UnmarshalText = %v, want %v", got, ct)
+	}
+	if err := got.UnmarshalText([]byte("not a time")); err == nil {
+		t.Errorf("UnmarshalText(garbage): expected error")
+	}
+}
+
+func TestJSONMapKey(t *testing.T) {
+	in := map[CTX]int{
+		NewCTX(time.Unix(0, 0)):          1,
+		NewCTX(time.Unix(0, 0).Add(1e9)): 2,
+		NewCTX(time.Unix(-3600, 0)):      3,
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var out map[CTX]int
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(out) != len(in) {
+		t.Fatalf("Unmarshal = %v, want %v", out, in)
+	}
+	for k, v := range in {
+		if out[k] != v {
+			t.Errorf("out[%v] = %d, want %d", k, out[k], v)
+		}
+	}
+}
+
+func TestHex(t *testing.T) {
+	ct := CTX(0xC0012AFF)
+	if got, want := ct.Hex(), "c0012aff"; got != want {
+		t.Errorf("Hex() = %q, want %q", got, want)
+	}
+
+	for _, s := range []string{"c0012aff", "C0012AFF"} {
+		got, err := ParseHex(s)
+		if err != nil || got != ct {
+			t.Errorf("ParseHex(%q) = %v, %v; want %v, nil", s, got, err, ct)
+		}
+	}
+
+	now := NewCTX(time.Now())
+	if got, err := ParseHex(now.Hex()); err != nil || got != now {
+		t.Errorf("ParseHex(Hex()) = %v, %v; want %v, nil", got, err, now)
+	}
+
+	for _, bad := range []string{"", "c0012af", "c0012aff00", "c0012afg"} {
+		if _, err := ParseHex(bad); err == nil {
+			t.Errorf("ParseHex(%q): expected error", bad)
+		}
+	}
+	if _, err := ParseHex("abc"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("ParseHex(odd length): err = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestBase64(t *testing.T) {
+	ct := CTX(0xC0012AFF)
+	if got, want := ct.Base64(), "wAEq_w"; got != want {
+		t.Errorf("Base64() = %q, want %q", got, want)
+	}
+
+	now := NewCTX(time.Now())
+	if got, err := ParseBase64(now.Base64()); err != nil || got != now {
+		t.Errorf("ParseBase64(Base64()) = %v, %v; want %v, nil", got, err, now)
+	}
+
+	for _, bad := range []string{"wAEq_w==", "wAEq/w", "wAE", "wAEq_wAA"} {
+		if _, err := ParseBase64(bad); err == nil {
+			t.Errorf("ParseBase64(%q): expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package ctx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// MessagePack heads of the timestamp extension type (-1) in its 32, 64 and
+// 96-bit forms.
+const (
+	msgpackFixext4   = 0xD6
+	msgpackFixext8   = 0xD7
+	msgpackExt8      = 0xC7
+	msgpackTimestamp = 0xFF // ext type -1
+)
+
+var errMsgpack = fmt.Errorf("%w: MessagePack value is not a timestamp extension", ErrInvalidFormat)
+
+// MarshalMsgpack implements the Marshaler interface of
+// github.com/vmihailenco/msgpack without depending on it. The decoded time
+// is written as the timestamp extension, using the smallest of its three
+// forms that holds it.
+func (c CTX) MarshalMsgpack() ([]byte, error) {
+	t := c.Time()
+	sec, nsec := t.Unix(), uint64(t.Nanosecond())
+	switch {
+	case nsec == 0 && sec >= 0 && sec>>32 == 0:
+		b := []byte{msgpackFixext4, msgpackTimestamp}
+		return binary.BigEndian.AppendUint32(b, uint32(sec)), nil
+	case sec >= 0 && sec>>34 == 0:
+		b := []byte{msgpackFixext8, msgpackTimestamp}
+		return binary.BigEndian.AppendUint64(b, nsec<<34|uint64(sec)), nil
+	}
+	b := []byte{msgpackExt8, 12, msgpackTimestamp}
+	b = binary.BigEndian.AppendUint32(b, uint32(nsec))
+	return binary.BigEndian.AppendUint64(b, uint64(sec)), nil
+}
+
+// UnmarshalMsgpack implements the Unmarshaler interface of
+// github.com/vmihailenco/msgpack. It accepts any of the three timestamp
+// extension forms and encodes the time with NewCTX.
+func (c *CTX) UnmarshalMsgpack(b []byte) error {
+	var sec, nsec int64
+	switch {
+	case len(b) == 6 && b[0] == msgpackFixext4 && b[1] == msgpackTimestamp:
+		sec = int64(binary.BigEndian.Uint32(b[2:]))
+	case len(b) == 10 && b[0] == msgpackFixext8 && b[1] == msgpackTimestamp:
+		v := binary.BigEndian.Uint64(b[2:])
+		sec, nsec = int64(v&(1<<34-1)), int64(v>>34)
+	case len(b) == 15 && b[0] == msgpackExt8 && b[1] == 12 && b[2] == msgpackTimestamp:
+		nsec = int64(binary.BigEndian.Uint32(b[3:]))
+		sec = int64(binary.BigEndian.Uint64(b[7:]))
+	default:
+		return errMsgpack
+	}
+	if nsec >= 1e9 {
+		return errMsgpack
+	}
+	*c = NewCTX(time.Unix(sec, nsec))
+	return nil
+}
@@ -0,0 +1,82 @@
+package ctx
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestMsgpack(t *testing.T) {
+	epoch := Epoch()
+	tests := []struct {
+		name string
+		ct   CTX
+		want []byte
+	}{
+		{"timestamp32", NewCTX(epoch.Add(90 * time.Second)), []byte{0xD6, 0xFF, 0, 0, 0, 0x5A}},
+		{"timestamp64", NewCTX(epoch.Add(1500 * time.Millisecond)), []byte{0xD7, 0xFF, 0x77, 0x35, 0x94, 0x00, 0, 0, 0, 0x01}},
+		{"timestamp96", NewCTX(epoch.Add(-time.Second)), []byte{0xC7, 0x0C, 0xFF, 0, 0, 0, 0, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.ct.MarshalMsgpack()
+			if err != nil {
+				t.Fatalf("MarshalMsgpack: %v", err)
+			}
+			if !bytes.Equal(b, tt.want) {
+				t.Errorf("MarshalMsgpack = % X, want % X", b, tt.want)
+			}
+			var got CTX
+			if err := got.UnmarshalMsgpack(b); err != nil {
+				t.Fatalf("UnmarshalMsgpack: %v", err)
+			}
+			if got != tt.ct {
+				t.Errorf("UnmarshalMsgpack = %v, want %v", got, tt.ct)
+			}
+		})
+	}
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	for _, tm := range []time.Time{time.Now(), Epoch().Add(1234567 * time.Microsecond), MinTime(), MaxTime()} {
+		ct := NewCTX(tm)
+		b, err := ct.MarshalMsgpack()
+		if err != nil {
+			t.Fatalf("MarshalMsgpack: %v", err)
+		}
+		var got CTX
+		if err := got.UnmarshalMsgpack(b); err != nil {
+			t.Fatalf("UnmarshalMsgpack(% X): %v", b, err)
+		}
+		if got != ct {
+			t.Errorf("round-trip of %v = %v via % X", ct, got, b)
+		}
+	}
+}
+
+func TestMsgpackDecodeForeign(t *testing.T) {
+	// time.Unix(1700000000, 500000000) in the 64-bit form used by conforming
+	// encoders such as vmihailenco/msgpack
+	in := []byte{0xD7, 0xFF, 0x77, 0x35, 0x94, 0x00, 0x65, 0x53, 0xF1, 0x00}
+	var got CTX
+	if err := got.UnmarshalMsgpack(in); err != nil {
+		t.Fatalf("UnmarshalMsgpack: %v", err)
+	}
+	if want := NewCTX(time.Unix(1700000000, 500000000)); got != want {
+		t.Errorf("UnmarshalMsgpack = %v, want %v", got, want)
+	}
+
+	for _, bad := range [][]byte{
+		nil,
+		{0xD6, 0x01, 0, 0, 0, 0}, // other extension type
+		{0xD6, 0xFF, 0, 0, 0},    // truncated
+		{0xCE, 0, 0, 0, 0},       // uint32
+		{0xD7, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}, // nanoseconds >= 1e9
+	} {
+		var c CTX
+		if err := c.UnmarshalMsgpack(bad); err == nil {
+			t.Errorf("UnmarshalMsgpack(% X): expected error", bad)
+		}
+	}
+}
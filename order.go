@@ -0,0 +1,72 @@
+package ctx
+
+const (
+	mantissaBits = valueBits + fracBits
+	mantissaMask = 1<<mantissaBits - 1
+	positiveKey  = 1 << 31
+)
+
+// SortableBytes returns a 4-byte big-endian form of c whose lexicographic
+// order matches chronological order, for use as a key in sorted stores.
+// It is not the same as Bytes; decode it with FromSortableBytes.
+func (c CTX) SortableBytes() []byte {
+	return CTX(c.sortKey()).Bytes()
+}
+
+// FromSortableBytes decodes the output of SortableBytes. The result is the
+// canonical encoding of the instant, as produced by NewCTX.
+func FromSortableBytes(b []byte) CTX {
+	if len(b) != 4 {
+		return 0
+	}
+	return fromSortKey(uint32(FromBytes(b)))
+}
+
+// fromSortKey inverts sortKey.
+func fromSortKey(k uint32) CTX {
+	var sign uint32
+	if k < positiveKey {
+		k = positiveKey - 1 - k
+		sign = signMask
+	} else {
+		k -= positiveKey
+	}
+	e := k >> mantissaBits
+	m := k & mantissaMask
+	scale := min(e, scaleSecond)
+	extra := e - scale
+	if m == 0 {
+		sign = 0
+	}
+
+	return CTX(scale<<scaleShift | sign | (m>>fracBits)<<valueShift |
+		(extra&0xF)<<extraShift | m&fracMask)
+}
+
+// sortKey packs the exponent and mantissa of the canonical encoding of c
+// above a sign offset: larger magnitudes sort higher for positive offsets
+// and lower for negative ones.
+func (c CTX) sortKey() uint32 {
+	n := c.nanos()
+	canon := c.Canonical()
+	k := uint32(canon.exponent())<<mantissaBits | uint32(canon.mantissa())
+	if n < 0 {
+		return positiveKey - 1 - k
+	}
+	return positiveKey + k
+}
+
+// OrderKey returns a uint64 whose integer order matches the chronological
+// order of the decoded instants, for use as a key in ordered structures.
+// It is the nanosecond offset from the epoch with the sign bit flipped, so
+// instants before the epoch sort below those after it. Equal instants
+// share a key even when their encodings differ.
+func (c CTX) OrderKey() uint64 {
+	return uint64(c.nanos()) ^ 1<<63
+}
+
+// FromOrderKey decodes the output of OrderKey, returning the canonical
+// encoding of the instant. Other keys decode to the tick containing them.
+func FromOrderKey(k uint64) CTX {
+	return fromNanos(int64(k ^ 1<<63))
+}
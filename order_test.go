@@ -0,0 +1,70 @@
+package ctx
+
+import (
+	"bytes"
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSortableBytes(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	offsets := []time.Duration{
+		-100 * 365 * 24 * time.Hour,
+		-24 * time.Hour,
+		-time.Second,
+		-time.Millisecond,
+		-time.Nanosecond,
+		0,
+		time.Nanosecond,
+		100 * time.Microsecond,
+		131072 * time.Nanosecond,
+		time.Second,
+		24 * time.Hour,
+		50 * 365 * 24 * time.Hour,
+		time.Since(epoch),
+	}
+
+	var prev []byte
+	for i, d := range offsets {
+		ct := NewCTX(epoch.Add(d))
+		b := ct.SortableBytes()
+		if i > 0 && bytes.Compare(prev, b) >= 0 {
+			t.Errorf("SortableBytes(%v) = % X, not after % X", d, b, prev)
+		}
+		if got := FromSortableBytes(b); got != ct {
+			t.Errorf("FromSortableBytes(% X) = %08X, want %08X", b, uint32(got), uint32(ct))
+		}
+		prev = b
+	}
+
+	if got := FromSortableBytes([]byte{1, 2}); got != 0 {
+		t.Errorf("FromSortableBytes(short) = %08X, want 0", uint32(got))
+	}
+}
+
+func TestOrderKey(t *testing.T) {
+	values := []CTX{NewCTX(MinTime()), NewCTX(MaxTime()), 0, NewCTX(time.Unix(0, -1)), NewCTX(time.Unix(0, 1))}
+	for _, tm := range sampleTimes(50) {
+		values = append(values, NewCTX(tm), NewCTX(time.Unix(0, 0).Add(-time.Since(tm))))
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(values), func(i, j int) {
+		values[i], values[j] = values[j], values[i]
+	})
+
+	byKey := slices.Clone(values)
+	slices.SortFunc(byKey, func(a, b CTX) int { return cmp.Compare(a.OrderKey(), b.OrderKey()) })
+	byTime := slices.Clone(values)
+	slices.SortFunc(byTime, func(a, b CTX) int { return a.Time().Compare(b.Time()) })
+	if !slices.Equal(byKey, byTime) {
+		t.Errorf("sorting by OrderKey differs from sorting by Time")
+	}
+
+	for _, c := range values {
+		if got := FromOrderKey(c.OrderKey()); got != c {
+			t.Errorf("FromOrderKey(%#v.OrderKey()) = %#v", c, got)
+		}
+	}
+}
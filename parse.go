@@ -0,0 +1,54 @@
+package ctx
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parse parses s as, in order, an RFC 3339 timestamp, an RFC 1123 timestamp,
+// an integer count of Unix seconds or an integer count of Unix milliseconds,
+// returning the first that succeeds. An integer is read as seconds when that
+// lands in the representable range (1677 to 2262) and as milliseconds
+// otherwise, so current millisecond timestamps are not mistaken for seconds.
+// On failure the error joins the reason each format was rejected.
+func Parse(s string) (CTX, error) {
+	var errs []error
+	for _, layout := range []string{time.RFC3339, time.RFC1123, time.RFC1123Z} {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return NewCTX(t), nil
+		}
+		errs = append(errs, err)
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("Unix seconds or milliseconds: %w", err))
+		return 0, fmt.Errorf("%w: cannot parse %q: %w", ErrInvalidFormat, s, errors.Join(errs...))
+	}
+	if c, err := NewCTXChecked(time.Unix(n, 0)); err == nil {
+		return c, nil
+	}
+	return NewCTXChecked(time.UnixMilli(n))
+}
+
+// ParseString parses s as an RFC 3339 timestamp or as a signed duration
+// relative to the current time, such as "+12h" or "-30m". The sign is
+// required for the relative form, and time.Now is read when ParseString is
+// called.
+func ParseString(s string) (CTX, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return NewCTX(t), nil
+	}
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+		}
+		return NewCTX(time.Now().Add(d)), nil
+	}
+	return 0, fmt.Errorf("%w: %q: want RFC 3339 or a signed duration like +12h", ErrInvalidFormat, s)
+}
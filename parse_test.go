@@ -0,0 +1,83 @@
+package ctx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	ref := time.Date(2024, 3, 15, 12, 30, 45, 0, time.UTC)
+	tests := []struct {
+		name string
+		in   string
+		want time.Time
+	}{
+		{"RFC3339", "2024-03-15T12:30:45Z", ref},
+		{"RFC3339 offset", "2024-03-15T14:30:45+02:00", ref},
+		{"RFC1123", "Fri, 15 Mar 2024 12:30:45 UTC", ref},
+		{"RFC1123Z", "Fri, 15 Mar 2024 12:30:45 +0000", ref},
+		{"Unix seconds", "1710505845", ref},
+		{"negative Unix seconds", "-86400", Epoch().Add(-24 * time.Hour)},
+		{"Unix millis", "1710505845123", ref.Add(123 * time.Millisecond)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.in, err)
+			}
+			if want := NewCTX(tt.want); got != want {
+				t.Errorf("Parse(%q) = %v, want %v", tt.in, got, want)
+			}
+		})
+	}
+
+	_, err := Parse("next tuesday")
+	if err == nil {
+		t.Fatal("Parse(invalid): expected error")
+	}
+	for _, want := range []string{"next tuesday", "Unix seconds or milliseconds", "parsing time"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestParseString(t *testing.T) {
+	got, err := ParseString("2024-03-15T12:30:45Z")
+	if err != nil {
+		t.Fatalf("ParseString(RFC 3339): %v", err)
+	}
+	if want := NewCTX(time.Date(2024, 3, 15, 12, 30, 45, 0, time.UTC)); got != want {
+		t.Errorf("ParseString(RFC 3339) = %v, want %v", got, want)
+	}
+
+	for _, tt := range []struct {
+		in string
+		d  time.Duration
+	}{
+		{"+12h", 12 * time.Hour},
+		{"-30m", -30 * time.Minute},
+		{"+1h30m", 90 * time.Minute},
+	} {
+		before := time.Now()
+		got, err := ParseString(tt.in)
+		after := time.Now()
+		if err != nil {
+			t.Fatalf("ParseString(%q): %v", tt.in, err)
+		}
+		lo, hi := NewCTX(before.Add(tt.d)), NewCTX(after.Add(tt.d))
+		if got.Before(lo) || got.After(hi) {
+			t.Errorf("ParseString(%q) = %v, want between %v and %v", tt.in, got, lo, hi)
+		}
+	}
+
+	for _, bad := range []string{"", "12h", "+12 hours", "tomorrow"} {
+		if _, err := ParseString(bad); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("ParseString(%q) error = %v, want ErrInvalidFormat", bad, err)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package ctx
+
+import "time"
+
+// PrecisionLoss returns how far NewCTX(t).Time() lands from t. For times in
+// range this is always less than NewCTX(t).Resolution().
+func PrecisionLoss(t time.Time) time.Duration {
+	return t.Sub(NewCTX(t).Time()).Abs()
+}
+
+// IsLossless reports whether NewCTX(t) decodes back to exactly t. Whole
+// seconds are lossless only within about 1.5 days of the epoch; present-day
+// times fall on a tick roughly every 65 minutes and are almost always lossy.
+func IsLossless(t time.Time) bool {
+	return NewCTX(t).Time().Equal(t)
+}
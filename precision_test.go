@@ -0,0 +1,51 @@
+package ctx
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestPrecisionLoss(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	samples := []time.Time{Epoch(), time.Now(), MinTime(), MaxTime()}
+	for i := 0; i < 1000; i++ {
+		// Spread offsets over every scale bucket
+		n := int64(r.Uint64()) >> r.Intn(64)
+		samples = append(samples, time.Unix(0, n))
+	}
+
+	for _, tm := range samples {
+		loss := PrecisionLoss(tm)
+		if res := NewCTX(tm).Resolution(); loss < 0 || loss >= res {
+			t.Errorf("PrecisionLoss(%v) = %v, want in [0, %v)", tm, loss, res)
+		}
+	}
+
+	if got := PrecisionLoss(Epoch().Add(time.Second)); got != 0 {
+		t.Errorf("PrecisionLoss(epoch+1s) = %v, want 0", got)
+	}
+}
+
+func TestIsLossless(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"epoch", Epoch(), true},
+		{"second-aligned", Epoch().Add(90 * time.Second), true},
+		{"nanosecond-bearing", Epoch().Add(90*time.Second + time.Nanosecond), false},
+		{"present-day second", time.Date(2024, 3, 15, 12, 30, 45, 0, time.UTC), false},
+		{"present-day tick", NewCTX(time.Now()).Time(), true},
+		{"clamped", MaxTime().Add(time.Hour), false},
+	}
+	for _, tt := range tests {
+		if got := IsLossless(tt.t); got != tt.want {
+			t.Errorf("%s: IsLossless(%v) = %v, want %v", tt.name, tt.t, got, tt.want)
+		}
+		if got := NewCTX(tt.t).Time().Equal(tt.t); got != tt.want {
+			t.Errorf("%s: round-trip equality %v disagrees", tt.name, got)
+		}
+	}
+}
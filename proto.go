@@ -0,0 +1,16 @@
+package ctx
+
+import "google.golang.org/protobuf/types/known/timestamppb"
+
+// Proto returns the decoded time of c as a google.protobuf.Timestamp.
+func (c CTX) Proto() *timestamppb.Timestamp {
+	return timestamppb.New(c.Time())
+}
+
+// FromProto encodes ts with NewCTX. A nil ts returns the zero CTX.
+func FromProto(ts *timestamppb.Timestamp) CTX {
+	if ts == nil {
+		return 0
+	}
+	return NewCTX(ts.AsTime())
+}
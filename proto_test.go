@@ -0,0 +1,39 @@
+package ctx
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestProto(t *testing.T) {
+	for _, tm := range []time.Time{time.Now(), Epoch().Add(-1500 * time.Millisecond), MinTime(), MaxTime()} {
+		ct := NewCTX(tm)
+		ts := ct.Proto()
+		if err := ts.CheckValid(); err != nil {
+			t.Fatalf("Proto(%v): %v", ct, err)
+		}
+		if !ts.AsTime().Equal(ct.Time()) {
+			t.Errorf("Proto(%v) = %v, want %v", ct, ts.AsTime(), ct.Time())
+		}
+
+		// Round-trip through the wire format as a gRPC service would
+		b, err := proto.Marshal(ts)
+		if err != nil {
+			t.Fatalf("proto.Marshal: %v", err)
+		}
+		var decoded timestamppb.Timestamp
+		if err := proto.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("proto.Unmarshal: %v", err)
+		}
+		if got := FromProto(&decoded); got != ct {
+			t.Errorf("FromProto(Proto(%v)) = %v", ct, got)
+		}
+	}
+
+	if got := FromProto(nil); got != 0 {
+		t.Errorf("FromProto(nil) = %v, want 0", got)
+	}
+}
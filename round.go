@@ -0,0 +1,74 @@
+package ctx
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+// RoundMode selects how NewCTXRound handles instants that fall between two
+// encodable values. Modes act on the distance from the epoch, so for
+// instants before the epoch RoundDown moves later and RoundUp earlier.
+type RoundMode int
+
+const (
+	// RoundDown truncates toward the epoch, as NewCTX does. The decoded time
+	// is never farther from the epoch than the input, and the error is
+	// below one Resolution.
+	RoundDown RoundMode = iota
+
+	// RoundNearest picks the closer encodable value, rounding ties away from
+	// the epoch. It halves the worst-case error of RoundDown and makes it
+	// symmetric.
+	RoundNearest
+
+	// RoundUp rounds away from the epoch. The decoded time is never closer
+	// to the epoch than the input.
+	RoundUp
+)
+
+// NewCTXRound is like NewCTX but rounds according to mode. Times outside the
+// representable range are clamped as by NewCTX.
+func NewCTXRound(t time.Time, mode RoundMode) CTX {
+	diff := int64(t.Sub(Epoch()))
+	c := fromNanos(diff)
+	if mode == RoundDown {
+		return c
+	}
+
+	mag := uint64(diff)
+	if diff < 0 {
+		mag = -mag
+	}
+	lo := c.magnitude()
+	hi, ok := c.nextMagnitude()
+	if lo == mag || !ok {
+		return c
+	}
+	if mode == RoundNearest && mag-lo < hi-mag {
+		return c
+	}
+	if diff < 0 {
+		return fromNanos(-int64(hi))
+	}
+	return fromNanos(int64(hi))
+}
+
+// nextMagnitude returns the decoded magnitude of the encoding one mantissa
+// step farther from the epoch than c, and false if it does not fit in int64
+// nanoseconds. At the top of a unit the next step is the first value of the
+// coarser unit, which decodes to the same magnitude.
+func (c CTX) nextMagnitude() (uint64, bool) {
+	e := c.exponent()
+	if e >= len(unitNanos) {
+		return 0, false
+	}
+	hi, lo := bits.Mul64(c.mantissa()+1, unitNanos[e])
+	lo, carry := bits.Add64(lo, fracMultiple-1, 0)
+	hi += carry
+	if hi>>fracBits != 0 {
+		return 0, false
+	}
+	mag := hi<<(64-fracBits) | lo>>fracBits
+	return mag, mag <= math.MaxInt64
+}
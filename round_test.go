@@ -0,0 +1,83 @@
+package ctx
+
+import (
+	"testing"
+	"time"
+)
+
+func roundSamples() []time.Time {
+	epoch := Epoch()
+	ts := sampleTimes(200)
+	for _, d := range []time.Duration{
+		1, 1234567, 131071999, 131072001, 987654321, 90*time.Minute + 1, 400 * 24 * time.Hour,
+	} {
+		ts = append(ts, epoch.Add(d), epoch.Add(-d))
+	}
+	return ts
+}
+
+func TestNewCTXRound(t *testing.T) {
+	epoch := Epoch()
+	for _, tm := range roundSamples() {
+		down := NewCTXRound(tm, RoundDown)
+		nearest := NewCTXRound(tm, RoundNearest)
+		up := NewCTXRound(tm, RoundUp)
+
+		if want := NewCTX(tm); down != want {
+			t.Errorf("RoundDown(%v) = %v, want NewCTX result %v", tm, down, want)
+		}
+
+		// Distances from the epoch: down <= input <= up
+		dist := tm.Sub(epoch).Abs()
+		dDown := down.Time().Sub(epoch).Abs()
+		dUp := up.Time().Sub(epoch).Abs()
+		if dDown > dist {
+			t.Errorf("RoundDown(%v) = %v is farther from the epoch", tm, down)
+		}
+		if dUp < dist {
+			t.Errorf("RoundUp(%v) = %v is closer to the epoch", tm, up)
+		}
+		if dUp-dDown > down.Resolution() {
+			t.Errorf("RoundDown and RoundUp of %v are %v apart, more than one tick", tm, dUp-dDown)
+		}
+
+		errDown := down.Time().Sub(tm).Abs()
+		errNearest := nearest.Time().Sub(tm).Abs()
+		if errNearest > errDown || errNearest > (nearest.Resolution()+1)/2 {
+			t.Errorf("RoundNearest(%v) error %v, RoundDown error %v, resolution %v",
+				tm, errNearest, errDown, nearest.Resolution())
+		}
+	}
+}
+
+func TestNewCTXRoundBias(t *testing.T) {
+	// Over evenly spread inputs, RoundDown errs early, RoundUp late and
+	// RoundNearest roughly cancels out.
+	base := Epoch().Add(10 * time.Second)
+	var sum [3]time.Duration
+	for i := 0; i < 1000; i++ {
+		tm := base.Add(time.Duration(i) * 104729)
+		for mode := RoundDown; mode <= RoundUp; mode++ {
+			sum[mode] += NewCTXRound(tm, mode).Time().Sub(tm)
+		}
+	}
+	res := NewCTX(base).Resolution()
+	if sum[RoundDown] >= 0 || sum[RoundUp] <= 0 {
+		t.Errorf("bias: down %v, up %v", sum[RoundDown], sum[RoundUp])
+	}
+	if sum[RoundNearest].Abs() > 1000*res/10 {
+		t.Errorf("RoundNearest bias %v exceeds a tenth of a tick per sample (%v)", sum[RoundNearest], res)
+	}
+}
+
+func TestNewCTXRoundExact(t *testing.T) {
+	tm := Epoch().Add(90 * time.Second)
+	for mode := RoundDown; mode <= RoundUp; mode++ {
+		if got := NewCTXRound(tm, mode); got != NewCTX(tm) {
+			t.Errorf("mode %d: exact time %v encoded to %v", mode, tm, got)
+		}
+	}
+	if got := NewCTXRound(MaxTime(), RoundUp); got != NewCTX(MaxTime()) {
+		t.Errorf("RoundUp(MaxTime()) = %v, want %v", got, NewCTX(MaxTime()))
+	}
+}
@@ -0,0 +1,9 @@
+package ctx
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer so CTX attributes are logged as their
+// decoded time rather than the raw integer.
+func (c CTX) LogValue() slog.Value {
+	return slog.TimeValue(c.Time())
+}
@@ -0,0 +1,46 @@
+package ctx
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// recordHandler keeps the last record it handled.
+type recordHandler struct {
+	record slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.record = r
+	return nil
+}
+
+func TestLogValue(t *testing.T) {
+	ct := NewCTX(time.Now())
+	h := &recordHandler{}
+	slog.New(h).Info("event", "at", ct)
+
+	var found bool
+	h.record.Attrs(func(a slog.Attr) bool {
+		if a.Key != "at" {
+			return true
+		}
+		found = true
+		v := a.Value.Resolve()
+		if v.Kind() != slog.KindTime {
+			t.Errorf("attribute kind = %v, want %v", v.Kind(), slog.KindTime)
+		} else if !v.Time().Equal(ct.Time()) {
+			t.Errorf("attribute = %v, want %v", v.Time(), ct.Time())
+		}
+		return false
+	})
+	if !found {
+		t.Error("attribute \"at\" not logged")
+	}
+}
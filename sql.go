@@ -0,0 +1,61 @@
+package ctx
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Value implements driver.Valuer. The value is stored as its 4 bytes.
+func (c CTX) Value() (driver.Value, error) {
+	return c.Bytes(), nil
+}
+
+// Scan implements sql.Scanner. It accepts the 4-byte form, the raw integer
+// as int64, or a time.Time. A NULL source sets the value to zero.
+func (c *CTX) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*c = 0
+		return nil
+	case []byte:
+		return c.UnmarshalBinary(v)
+	case int64:
+		if v < 0 || v > math.MaxUint32 {
+			return fmt.Errorf("%w: raw value %d", ErrOutOfRange, v)
+		}
+		*c = CTX(v)
+		return nil
+	case time.Time:
+		*c = NewCTX(v)
+		return nil
+	default:
+		return fmt.Errorf("%w: cannot scan %T into CTX", ErrInvalidFormat, src)
+	}
+}
+
+// NullCTX represents a CTX that may be NULL, mirroring sql.NullTime.
+type NullCTX struct {
+	CTX   CTX
+	Valid bool // Valid is true if CTX is not NULL
+}
+
+// Scan implements sql.Scanner. A NULL source sets Valid to false; any other
+// source is scanned as by CTX.Scan.
+func (n *NullCTX) Scan(src any) error {
+	if src == nil {
+		n.CTX, n.Valid = 0, false
+		return nil
+	}
+	n.Valid = true
+	return n.CTX.Scan(src)
+}
+
+// Value implements driver.Valuer, returning nil when n is not valid.
+func (n NullCTX) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.CTX.Value()
+}
@@ -0,0 +1,100 @@
+package ctx
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestValue(t *testing.T) {
+	ct := NewCTX(time.Now())
+	v, err := ct.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	if b, ok := v.([]byte); !ok || !bytes.Equal(b, ct.Bytes()) {
+		t.Errorf("Value = %v, want % X", v, ct.Bytes())
+	}
+}
+
+func TestScan(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	raw := CTX(0xC0012345)
+
+	tests := []struct {
+		name string
+		src  any
+		want CTX
+	}{
+		{"bytes", raw.Bytes(), raw},
+		{"int64", int64(raw), raw},
+		{"time", epoch, NewCTX(epoch)},
+		{"nil", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := CTX(0xFFFFFFFF)
+			if err := ct.Scan(tt.src); err != nil {
+				t.Fatalf("Scan: %v", err)
+			}
+			if ct != tt.want {
+				t.Errorf("Scan = %08X, want %08X", uint32(ct), uint32(tt.want))
+			}
+		})
+	}
+
+	for _, bad := range []any{[]byte{1, 2}, int64(-1), int64(1) << 32, "2024-01-01"} {
+		var ct CTX
+		if err := ct.Scan(bad); err == nil {
+			t.Errorf("Scan(%#v): expected error", bad)
+		}
+	}
+}
+
+func TestNullCTX(t *testing.T) {
+	now := time.Now()
+	ct := NewCTX(now)
+
+	tests := []struct {
+		name string
+		src  any
+		want NullCTX
+	}{
+		{"null", nil, NullCTX{}},
+		{"bytes", ct.Bytes(), NullCTX{CTX: ct, Valid: true}},
+		{"time", now, NullCTX{CTX: ct, Valid: true}},
+		{"epoch", time.Unix(0, 0), NullCTX{CTX: 0, Valid: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NullCTX{CTX: 0xFFFFFFFF, Valid: !tt.want.Valid}
+			if err := n.Scan(tt.src); err != nil {
+				t.Fatalf("Scan: %v", err)
+			}
+			if n != tt.want {
+				t.Errorf("Scan = %+v, want %+v", n, tt.want)
+			}
+
+			v, err := n.Value()
+			if err != nil {
+				t.Fatalf("Value: %v", err)
+			}
+			if !n.Valid {
+				if v != nil {
+					t.Errorf("Value = %v, want nil", v)
+				}
+				return
+			}
+			if b, ok := v.([]byte); !ok || !bytes.Equal(b, n.CTX.Bytes()) {
+				t.Errorf("Value = %v, want % X", v, n.CTX.Bytes())
+			}
+		})
+	}
+
+	var n NullCTX
+	if err := n.Scan("2024-01-01"); err == nil {
+		t.Errorf("Scan(string): expected error")
+	}
+}
@@ -0,0 +1,132 @@
+package ctx
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// A Decoder reads back-to-back 4-byte CTX records from an input stream,
+// buffering the underlying reads.
+type Decoder struct {
+	r   *bufio.Reader
+	buf [ctxSize]byte
+}
+
+// NewDecoder returns a Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next record. It returns io.EOF when the stream ends
+// cleanly between records and io.ErrUnexpectedEOF when it ends part-way
+// through one.
+func (d *Decoder) Decode() (CTX, error) {
+	if _, err := io.ReadFull(d.r, d.buf[:]); err != nil {
+		return 0, err
+	}
+	return FromArray(d.buf), nil
+}
+
+// An Encoder writes back-to-back 4-byte CTX records to an output stream,
+// buffering the underlying writes. Call Flush once all records are encoded.
+type Encoder struct {
+	w   *bufio.Writer
+	buf [ctxSize]byte
+}
+
+// NewEncoder returns an Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes t as the next record.
+func (e *Encoder) Encode(t time.Time) error {
+	return e.EncodeCTX(NewCTX(t))
+}
+
+// EncodeCTX writes c as the next record.
+func (e *Encoder) EncodeCTX(c CTX) error {
+	e.buf = c.Array()
+	_, err := e.w.Write(e.buf[:])
+	return err
+}
+
+// Flush writes any buffered records to the underlying writer.
+func (e *Encoder) Flush() error {
+	return e.w.Flush()
+}
+
+// bufferedWriterSize is the number of bytes a BufferedWriter collects
+// before flushing: 1024 records.
+const bufferedWriterSize = 1024 * ctxSize
+
+// bufferPool holds the buffers of closed BufferedWriters for reuse.
+var bufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, bufferedWriterSize)
+		return &b
+	},
+}
+
+var errWriterClosed = errors.New("ctx: write to closed BufferedWriter")
+
+// A BufferedWriter batches 4-byte CTX records and writes them to an
+// underlying writer 1024 records at a time. Unlike Encoder, its buffer comes
+// from a package-wide sync.Pool and goes back on Close, so servers that open
+// many short-lived writers do not allocate a buffer for each.
+//
+// Records reach the underlying writer only when the buffer fills, on Flush
+// or on Close. A BufferedWriter must not be used after Close.
+type BufferedWriter struct {
+	w   io.Writer
+	buf *[]byte
+}
+
+// NewBufferedWriter returns a BufferedWriter that writes to w.
+func NewBufferedWriter(w io.Writer) *BufferedWriter {
+	return &BufferedWriter{w: w, buf: bufferPool.Get().(*[]byte)}
+}
+
+// Write buffers t as the next record, flushing first if the buffer is full.
+func (bw *BufferedWriter) Write(t time.Time) error {
+	if bw.buf == nil {
+		return errWriterClosed
+	}
+	if len(*bw.buf) == bufferedWriterSize {
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+	}
+	*bw.buf = NewCTX(t).AppendBytes(*bw.buf)
+	return nil
+}
+
+// Flush writes all buffered records to the underlying writer. On error the
+// records stay buffered.
+func (bw *BufferedWriter) Flush() error {
+	if bw.buf == nil {
+		return errWriterClosed
+	}
+	if len(*bw.buf) == 0 {
+		return nil
+	}
+	if _, err := bw.w.Write(*bw.buf); err != nil {
+		return err
+	}
+	*bw.buf = (*bw.buf)[:0]
+	return nil
+}
+
+// Close flushes the buffered records and returns the buffer to the pool. It
+// does not close the underlying writer.
+func (bw *BufferedWriter) Close() error {
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	bufferPool.Put(bw.buf)
+	bw.buf = nil
+	return nil
+}
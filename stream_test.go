@@ -0,0 +1,113 @@
+package ctx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestDecoder(t *testing.T) {
+	ts := sampleTimes(1000)
+	b := EncodeAll(ts)
+
+	// OneByteReader forces records to span reads of the underlying stream
+	dec := NewDecoder(iotest.OneByteReader(bytes.NewReader(b)))
+	for i, tm := range ts {
+		c, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Decode %d: %v", i, err)
+		}
+		if want := NewCTX(tm); c != want {
+			t.Errorf("Decode %d = %v, want %v", i, c, want)
+		}
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("Decode at end = %v, want io.EOF", err)
+	}
+}
+
+func TestDecoderPartialRecord(t *testing.T) {
+	b := EncodeAll(sampleTimes(2))
+	dec := NewDecoder(bytes.NewReader(b[:len(b)-1]))
+	if _, err := dec.Decode(); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if _, err := dec.Decode(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Decode of partial record = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	ts := sampleTimes(10000)
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	for _, tm := range ts {
+		if err := enc.Encode(tm); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), EncodeAll(ts)) {
+		t.Fatalf("Encoder output differs from EncodeAll")
+	}
+
+	dec := NewDecoder(&buf)
+	for i, tm := range ts {
+		c, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Decode %d: %v", i, err)
+		}
+		if want := NewCTX(tm); c != want {
+			t.Errorf("Decode %d = %v, want %v", i, c, want)
+		}
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("Decode at end = %v, want io.EOF", err)
+	}
+}
+
+func TestBufferedWriter(t *testing.T) {
+	ts := sampleTimes(2500) // spans two full buffers and a partial one
+	var buf bytes.Buffer
+	bw := NewBufferedWriter(&buf)
+	for i, tm := range ts {
+		if err := bw.Write(tm); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if i == 1023 && buf.Len() != 0 {
+			t.Fatalf("records written before the buffer filled")
+		}
+	}
+	if buf.Len() != 2048*ctxSize {
+		t.Errorf("before Close, %d bytes written, want %d", buf.Len(), 2048*ctxSize)
+	}
+	if err := bw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), EncodeAll(ts)) {
+		t.Errorf("BufferedWriter output differs from EncodeAll")
+	}
+	if err := bw.Write(ts[0]); err == nil {
+		t.Errorf("Write after Close: expected error")
+	}
+}
+
+func BenchmarkBufferedWriter(b *testing.B) {
+	now := time.Now()
+	bw := NewBufferedWriter(io.Discard)
+	b.ReportAllocs()
+	b.SetBytes(ctxSize)
+	for i := 0; i < b.N; i++ {
+		if err := bw.Write(now); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := bw.Close(); err != nil {
+		b.Fatal(err)
+	}
+}
@@ -0,0 +1,18 @@
+package ctx
+
+import "time"
+
+// TimeCodec is implemented by every timestamp width in the package, so
+// storage layers can hold CTX, CTX48 and CTX64 values side by side. Decode
+// the bytes with the function matching the width: FromBytes, FromBytes48 or
+// FromBytes64.
+type TimeCodec interface {
+	Time() time.Time
+	Bytes() []byte
+}
+
+var (
+	_ TimeCodec = CTX(0)
+	_ TimeCodec = CTX48(0)
+	_ TimeCodec = CTX64(0)
+)
@@ -0,0 +1,22 @@
+package ctx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeCodec(t *testing.T) {
+	tm := time.Date(2024, 3, 15, 12, 30, 45, 123456789, time.UTC)
+	values := []TimeCodec{NewCTX(tm), NewCTX48(tm), NewCTX64(tm)}
+	widths := []int{4, 6, 8}
+	tolerances := []time.Duration{NewCTX(tm).Resolution(), time.Second / 1024, 0}
+
+	for i, v := range values {
+		if got := len(v.Bytes()); got != widths[i] {
+			t.Errorf("%T.Bytes() has %d bytes, want %d", v, got, widths[i])
+		}
+		if diff := tm.Sub(v.Time()); diff < 0 || diff > tolerances[i] {
+			t.Errorf("%T.Time() = %v, want within %v of %v", v, v.Time(), tolerances[i], tm)
+		}
+	}
+}
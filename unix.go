@@ -0,0 +1,144 @@
+package ctx
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// FromUnix encodes sec seconds since the Unix epoch. It matches
+// NewCTX(time.Unix(sec, 0)), clamping out-of-range values the same way.
+func FromUnix(sec int64) CTX {
+	return fromNanos(mulSat(sec, 1e9))
+}
+
+// minUnixSec and maxUnixSec bound the whole seconds whose nanosecond count
+// fits in an int64.
+const (
+	minUnixSec = math.MinInt64 / int64(time.Second)
+	maxUnixSec = math.MaxInt64 / int64(time.Second)
+)
+
+// FromSecondsSinceEpoch encodes sec whole seconds since the epoch, which is
+// the Unix epoch; counts from another epoch need its Unix offset added
+// first. The import is lossless: it returns an error wrapping ErrOutOfRange
+// unless the result decodes to exactly sec. Every second within about 1.5
+// days of the epoch qualifies; further out only seconds on a tick boundary
+// do, such as multiples of 15625 s for present-day times.
+func FromSecondsSinceEpoch(sec int64) (CTX, error) {
+	if sec < minUnixSec || sec > maxUnixSec {
+		return 0, fmt.Errorf("%w: %d seconds outside representable range [%d, %d]",
+			ErrOutOfRange, sec, minUnixSec, maxUnixSec)
+	}
+	n := sec * 1e9
+	c := fromNanos(n)
+	if c.nanos() != n {
+		return 0, fmt.Errorf("%w: %d seconds is not on a tick boundary (resolution %v)",
+			ErrOutOfRange, sec, c.Resolution())
+	}
+	return c, nil
+}
+
+// FromUnixNano encodes ns nanoseconds since the Unix epoch.
+func FromUnixNano(ns int64) CTX {
+	return fromNanos(ns)
+}
+
+// FromUnixMilli encodes ms milliseconds since the Unix epoch. It matches
+// NewCTX(time.UnixMilli(ms)).
+func FromUnixMilli(ms int64) CTX {
+	return fromNanos(mulSat(ms, 1e6))
+}
+
+// Unix returns c as seconds since the Unix epoch, like c.Time().Unix().
+func (c CTX) Unix() int64 {
+	return floorDiv(c.nanos(), 1e9)
+}
+
+// UnixNano returns c as nanoseconds since the Unix epoch, like
+// c.Time().UnixNano().
+func (c CTX) UnixNano() int64 {
+	return c.nanos()
+}
+
+// UnixMilli returns c as milliseconds since the Unix epoch, like
+// c.Time().UnixMilli(). FromUnixMilli(c.UnixMilli()) returns c only when c
+// decodes to a whole millisecond, as every value within about two minutes of
+// the epoch does. Further out, a tick after the epoch may start partway
+// through a millisecond, and rounding down then lands in the previous tick.
+func (c CTX) UnixMilli() int64 {
+	return floorDiv(c.nanos(), 1e6)
+}
+
+// unixMilliInTick returns a millisecond since the Unix epoch that lies in the
+// tick c decodes to whenever the tick is at least 1 ms wide, so
+// FromUnixMilli maps it back to c. A tick extends away from the epoch, so
+// positive offsets round up and negative ones round down.
+func (c CTX) unixMilliInTick() int64 {
+	if n := c.nanos(); n >= 0 {
+		return ceilDiv(n, 1e6)
+	}
+	return c.UnixMilli()
+}
+
+// SecondsNanos returns c as whole seconds since the Unix epoch and the
+// nanosecond within that second, like c.Time().Unix() and
+// c.Time().Nanosecond(), without building a time.Time.
+func (c CTX) SecondsNanos() (sec int64, nsec int64) {
+	n := c.nanos()
+	sec = floorDiv(n, 1e9)
+	return sec, n - sec*1e9
+}
+
+// Split returns c as whole seconds since the Unix epoch and the fraction of
+// the second in units of 1/65536 s, rounded up so it stays inside the tick c
+// decodes to. Join reverses it exactly for canonical values whose
+// Resolution is at least 1/65536 s, which holds for offsets beyond about two
+// minutes from the epoch.
+func (c CTX) Split() (seconds int64, fraction uint16) {
+	sec, nsec := c.SecondsNanos()
+	f := (nsec<<16 + 1e9 - 1) / 1e9
+	if f == 1<<16 {
+		return sec + 1, 0
+	}
+	return sec, uint16(f)
+}
+
+// Join encodes seconds since the Unix epoch plus fraction/65536 of a second,
+// as returned by Split. Out-of-range values are clamped like NewCTX.
+func Join(seconds int64, fraction uint16) CTX {
+	n := mulSat(seconds, 1e9)
+	if ns := (int64(fraction)*1e9 + 1<<16 - 1) >> 16; n <= math.MaxInt64-ns {
+		n += ns
+	}
+	return fromNanos(n)
+}
+
+// mulSat returns a*b clamped to the int64 range, for b > 0.
+func mulSat(a, b int64) int64 {
+	switch {
+	case a > math.MaxInt64/b:
+		return math.MaxInt64
+	case a < math.MinInt64/b:
+		return math.MinInt64
+	}
+	return a * b
+}
+
+// floorDiv returns a/b rounded toward negative infinity, for b > 0.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b < 0 {
+		q--
+	}
+	return q
+}
+
+// ceilDiv returns a/b rounded toward positive infinity, for a >= 0 and b > 0.
+func ceilDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 {
+		q++
+	}
+	return q
+}
@@ -0,0 +1,175 @@
+package ctx
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestUnix(t *testing.T) {
+	secs := []int64{0, 1, -1, 86400, -86400, 1700000000, time.Now().Unix()}
+	for _, sec := range secs {
+		ct := FromUnix(sec)
+		if want := NewCTX(time.Unix(sec, 0)); ct != want {
+			t.Errorf("FromUnix(%d) = %v, want %v", sec, ct, want)
+		}
+		if got, want := ct.Unix(), ct.Time().Unix(); got != want {
+			t.Errorf("FromUnix(%d).Unix() = %d, want %d", sec, got, want)
+		}
+	}
+
+	nanos := []int64{0, 1, -1, 999999999, -999999999, 1500000000, -1500000000, time.Now().UnixNano(), math.MaxInt64, math.MinInt64}
+	for _, ns := range nanos {
+		ct := FromUnixNano(ns)
+		if want := NewCTX(time.Unix(0, ns)); ct != want {
+			t.Errorf("FromUnixNano(%d) = %v, want %v", ns, ct, want)
+		}
+		if got, want := ct.UnixNano(), ct.Time().UnixNano(); got != want {
+			t.Errorf("FromUnixNano(%d).UnixNano() = %d, want %d", ns, got, want)
+		}
+		if got, want := ct.Unix(), ct.Time().Unix(); got != want {
+			t.Errorf("FromUnixNano(%d).Unix() = %d, want %d", ns, got, want)
+		}
+	}
+
+	if got, want := FromUnix(math.MaxInt64), NewCTX(time.Unix(0, math.MaxInt64)); got != want {
+		t.Errorf("FromUnix(MaxInt64) = %v, want clamped %v", got, want)
+	}
+	if got, want := FromUnix(math.MinInt64), NewCTX(time.Unix(0, math.MinInt64)); got != want {
+		t.Errorf("FromUnix(MinInt64) = %v, want clamped %v", got, want)
+	}
+}
+
+func TestUnixMilli(t *testing.T) {
+	millis := []int64{0, 1, -1, 999, -999, 131071, -131071, 1700000000123, time.Now().UnixMilli()}
+	for _, ms := range millis {
+		ct := FromUnixMilli(ms)
+		if want := NewCTX(time.UnixMilli(ms)); ct != want {
+			t.Errorf("FromUnixMilli(%d) = %v, want %v", ms, ct, want)
+		}
+		if got, want := ct.UnixMilli(), ct.Time().UnixMilli(); got != want {
+			t.Errorf("FromUnixMilli(%d).UnixMilli() = %d, want %d", ms, got, want)
+		}
+	}
+
+	// Within about two minutes of the epoch the round trip is exact
+	for _, ms := range []int64{1, -1, 1234, -98765, 131071} {
+		if got := FromUnixMilli(ms).UnixMilli(); got != ms {
+			t.Errorf("FromUnixMilli(%d).UnixMilli() = %d, want exact", ms, got)
+		}
+	}
+
+	// From about two minutes to four years out, ticks are at least 3.9 ms
+	// wide but need not start on a whole millisecond. The round trip is
+	// exact for those that do.
+	for d := 3 * time.Minute; d < 4*365*24*time.Hour; d = d*17/16 + 12345 {
+		for _, d := range []time.Duration{d, -d} {
+			ct := FromSinceEpoch(d)
+			if got := FromUnixMilli(ct.UnixMilli()); ct.nanos()%1e6 == 0 && got != ct {
+				t.Errorf("%v: FromUnixMilli(UnixMilli()) = %v, want %v for a whole-millisecond tick", d, got, ct)
+			}
+		}
+	}
+}
+
+func TestUnixMilliInTick(t *testing.T) {
+	// Where a tick is at least 1 ms wide, unixMilliInTick names a
+	// millisecond inside it even when UnixMilli falls in the previous tick.
+	offsets := []time.Duration{
+		4*24*time.Hour + 13*time.Hour + 16*time.Minute + 54*time.Second + 62500*time.Microsecond,
+		54*time.Minute + 6*time.Second + 855468750,
+		time.Hour + 123456789,
+		100*24*time.Hour + 7,
+	}
+	for d := 3 * time.Minute; d < 4*365*24*time.Hour; d = d*17/16 + 12345 {
+		offsets = append(offsets, d)
+	}
+	for _, d := range offsets {
+		for _, d := range []time.Duration{d, -d} {
+			ct := FromSinceEpoch(d)
+			if ct.Resolution() < time.Millisecond {
+				t.Fatalf("%v: resolution %v, want at least 1ms", d, ct.Resolution())
+			}
+			if got := FromUnixMilli(ct.unixMilliInTick()); got != ct {
+				t.Errorf("%v: FromUnixMilli(unixMilliInTick()) = %v, want %v", d, got, ct)
+			}
+		}
+	}
+}
+
+func TestSecondsNanos(t *testing.T) {
+	for _, tm := range append(sampleTimes(50), time.Unix(-2, 5e8), time.Unix(0, -1), MinTime(), MaxTime()) {
+		ct := NewCTX(tm)
+		sec, nsec := ct.SecondsNanos()
+		if want := ct.Time(); sec != want.Unix() || nsec != int64(want.Nanosecond()) {
+			t.Errorf("SecondsNanos(%v) = %d, %d; want %d, %d", ct, sec, nsec, want.Unix(), want.Nanosecond())
+		}
+	}
+}
+
+func BenchmarkSecondsNanos(b *testing.B) {
+	ct := NewCTX(time.Now())
+
+	b.Run("SecondsNanos", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = ct.SecondsNanos()
+		}
+	})
+
+	b.Run("Time", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			t := ct.Time()
+			_, _ = t.Unix(), t.Nanosecond()
+		}
+	})
+}
+
+func TestSplitJoin(t *testing.T) {
+	for _, tm := range sampleTimes(500) {
+		c := NewCTX(tm)
+		if c.Resolution() < time.Second>>16 {
+			continue
+		}
+		sec, frac := c.Split()
+		if got := Join(sec, frac); got != c {
+			t.Errorf("Join(%v.Split()) = %v, want %v (split %d, %d)", c, got, c, sec, frac)
+		}
+	}
+
+	if sec, frac := FromUnix(-2).Split(); sec != -2 || frac != 0 {
+		t.Errorf("FromUnix(-2).Split() = %d, %d; want -2, 0", sec, frac)
+	}
+	if sec, frac := FromUnixMilli(1500).Split(); sec != 1 || frac != 1<<15 {
+		t.Errorf("FromUnixMilli(1500).Split() = %d, %d; want 1, %d", sec, frac, 1<<15)
+	}
+	if got, want := Join(math.MaxInt64, math.MaxUint16), FromUnixNano(math.MaxInt64); got != want {
+		t.Errorf("Join(MaxInt64, MaxUint16) = %v, want clamped %v", got, want)
+	}
+}
+
+func TestFromSecondsSinceEpoch(t *testing.T) {
+	// 1700000000 is a multiple of 15625 s, a present-day tick boundary
+	for _, sec := range []int64{0, 3600, -86400, 131071, 1700000000} {
+		got, err := FromSecondsSinceEpoch(sec)
+		if err != nil {
+			t.Errorf("FromSecondsSinceEpoch(%d): %v", sec, err)
+			continue
+		}
+		if want := FromUnix(sec); got != want {
+			t.Errorf("FromSecondsSinceEpoch(%d) = %v, want %v", sec, got, want)
+		}
+		if !got.Time().Equal(time.Unix(sec, 0)) {
+			t.Errorf("FromSecondsSinceEpoch(%d).Time() = %v, want exact", sec, got.Time())
+		}
+	}
+
+	// 1700003000 lies inside the tick starting at 1700000000
+	for _, sec := range []int64{1700003000, maxUnixSec + 1, minUnixSec - 1, math.MaxInt64, math.MinInt64} {
+		if _, err := FromSecondsSinceEpoch(sec); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("FromSecondsSinceEpoch(%d): err = %v, want ErrOutOfRange", sec, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package ctx
+
+import (
+	"fmt"
+	"time"
+)
+
+// CTXZoned pairs a CTX with the UTC offset of the time it was created from,
+// so the original wall clock can be restored. Its serialized form is 6 bytes:
+// the 4 bytes of the CTX followed by the offset in minutes as a big-endian
+// int16. BytesWithZoneName appends the zone abbreviation as well.
+type CTXZoned struct {
+	CTX    CTX
+	Offset int16  // minutes east of UTC
+	Name   string // zone abbreviation such as "PST"; may be empty
+}
+
+// NewCTXZoned encodes t along with its zone offset and abbreviation. Offsets
+// are kept to the minute; any seconds component is dropped.
+func NewCTXZoned(t time.Time) CTXZoned {
+	name, offset := t.Zone()
+	return CTXZoned{CTX: NewCTX(t), Offset: int16(offset / 60), Name: name}
+}
+
+// Time returns the decoded instant in a fixed zone with the stored offset and
+// name.
+func (z CTXZoned) Time() time.Time {
+	return z.CTX.Time().In(time.FixedZone(z.Name, int(z.Offset)*60))
+}
+
+// BytesWithZone returns the 6-byte form of z. The zone name is not stored.
+func (z CTXZoned) BytesWithZone() []byte {
+	return append(z.CTX.Bytes(), byte(uint16(z.Offset)>>8), byte(z.Offset))
+}
+
+// BytesWithZoneName returns the 6-byte form of z followed by a length byte
+// and the zone name, so the abbreviation survives decoding. Names longer than
+// 255 bytes are truncated.
+func (z CTXZoned) BytesWithZoneName() []byte {
+	name := z.Name
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return append(append(z.BytesWithZone(), byte(len(name))), name...)
+}
+
+// FromBytesWithZone decodes the output of BytesWithZone or
+// BytesWithZoneName.
+func FromBytesWithZone(b []byte) (CTXZoned, error) {
+	if len(b) < 6 || len(b) > 6 && len(b) != 7+int(b[6]) {
+		return CTXZoned{}, fmt.Errorf("%w: got %d bytes, want 6 or 7 plus the name length", ErrInvalidLength, len(b))
+	}
+	z := CTXZoned{
+		CTX:    FromBytes(b[:4]),
+		Offset: int16(uint16(b[4])<<8 | uint16(b[5])),
+	}
+	if len(b) > 6 {
+		z.Name = string(b[7:])
+	}
+	return z, nil
+}
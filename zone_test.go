@@ -0,0 +1,74 @@
+package ctx
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCTXZoned(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset int
+	}{
+		{"ist", 5*3600 + 30*60},
+		{"utc", 0},
+		{"newfoundland", -(3*3600 + 30*60)},
+	}
+
+	now := time.Now()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := now.In(time.FixedZone("", tt.offset))
+			b := NewCTXZoned(in).BytesWithZone()
+			if len(b) != 6 {
+				t.Fatalf("BytesWithZone has %d bytes, want 6", len(b))
+			}
+
+			z, err := FromBytesWithZone(b)
+			if err != nil {
+				t.Fatalf("FromBytesWithZone: %v", err)
+			}
+			got := z.Time()
+			if _, offset := got.Zone(); offset != tt.offset {
+				t.Errorf("offset = %d, want %d", offset, tt.offset)
+			}
+			if diff := got.Sub(in).Abs(); diff > tolerance(in) {
+				t.Errorf("Time() = %v, want about %v", got, in)
+			}
+		})
+	}
+
+	if _, err := FromBytesWithZone(make([]byte, 4)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("FromBytesWithZone(4 bytes): err = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestCTXZonedName(t *testing.T) {
+	pst := time.FixedZone("PST", -8*3600)
+	in := time.Date(2024, 1, 15, 9, 30, 0, 0, pst)
+
+	b := NewCTXZoned(in).BytesWithZoneName()
+	if want := 6 + 1 + len("PST"); len(b) != want {
+		t.Fatalf("BytesWithZoneName has %d bytes, want %d", len(b), want)
+	}
+	z, err := FromBytesWithZone(b)
+	if err != nil {
+		t.Fatalf("FromBytesWithZone: %v", err)
+	}
+	if name, offset := z.Time().Zone(); name != "PST" || offset != -8*3600 {
+		t.Errorf("Zone() = %q, %d; want \"PST\", %d", name, offset, -8*3600)
+	}
+
+	z, err = FromBytesWithZone(NewCTXZoned(in).BytesWithZone())
+	if err != nil {
+		t.Fatalf("FromBytesWithZone: %v", err)
+	}
+	if z.Name != "" {
+		t.Errorf("6-byte form decoded with Name %q, want empty", z.Name)
+	}
+
+	if _, err := FromBytesWithZone(b[:len(b)-1]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("FromBytesWithZone(truncated name): err = %v, want ErrInvalidLength", err)
+	}
+}